@@ -1,3 +1,5 @@
 module github.com/maevadevs/Go-Developer-Advanced/Composite-Types
 
 go 1.26.1
+
+require github.com/rivo/uniseg v0.4.7
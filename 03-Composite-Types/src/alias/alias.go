@@ -0,0 +1,265 @@
+// Package alias reports which slices share a backing array.
+//
+// Slicing a slice does not copy it: both slices see the same elements, and an append
+// to one can overwrite the visible elements of the other when it has spare capacity.
+// Given named slices, Analyze finds the slices that share a backing array,
+// the elements they both see, and the appends that would clobber another slice.
+//
+// Backing arrays are compared by address, so the slices must be analyzed while they are alive.
+package alias
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unsafe"
+)
+
+// A slice with the name used in reports.
+type Named[T any] struct {
+	Name string
+	S    []T
+}
+
+// Name a slice for Analyze.
+func Of[T any](name string, s []T) Named[T] {
+	return Named[T]{Name: name, S: s}
+}
+
+// Two slices that see the same elements: A[ALo:AHi] is B[BLo:BHi].
+type Overlap struct {
+	A, B     string
+	ALo, AHi int
+	BLo, BHi int
+}
+
+func (o Overlap) String() string {
+	return fmt.Sprintf("%s[%d:%d] is %s[%d:%d]", o.A, o.ALo, o.AHi, o.B, o.BLo, o.BHi)
+}
+
+// An append that would overwrite elements of another slice without reallocating.
+// Appending After more values to Appender writes over Victim[Lo:Hi].
+type Clobber struct {
+	Appender, Victim string
+	// Number of values appended before the first overwrite, 1 when the next append does
+	After  int
+	Lo, Hi int
+}
+
+func (c Clobber) String() string {
+	return fmt.Sprintf("append to %s overwrites %s[%d:%d] from value %d on", c.Appender, c.Victim, c.Lo, c.Hi, c.After)
+}
+
+// The place of a slice in its backing array, in elements from the start of the group.
+type Layout struct {
+	Name string
+	// The visible elements are [Start:End), the capacity ends at CapEnd
+	Start, End, CapEnd int
+}
+
+// Slices that share a backing array.
+type Group struct {
+	Slices []Layout
+}
+
+// The aliasing between a set of slices.
+type Report struct {
+	Groups   []Group
+	Overlaps []Overlap
+	Clobbers []Clobber
+}
+
+// A slice as an address range.
+type span struct {
+	name            string
+	start, end, cap uintptr
+}
+
+// Find the slices that share a backing array, the elements they share,
+// and the appends that would overwrite another slice.
+// Slices with no capacity, and slices of zero-size elements, never share anything.
+//
+// Slices are grouped when their capacities overlap. Slices that full slice expressions
+// keep apart, like s[0:2:2] and s[2:4], do not overlap: use Within to group them by their array.
+func Analyze[T any](named ...Named[T]) Report {
+	size := unsafe.Sizeof(*new(T))
+	spans := spansOf(named)
+	// Slices whose capacities overlap are in the same allocation: group them
+	slices.SortStableFunc(spans, func(a, b span) int { return cmpUintptr(a.start, b.start) })
+	var groups [][]span
+	for i, s := range spans {
+		if i > 0 && s.start < groupEnd(groups[len(groups)-1]) {
+			groups[len(groups)-1] = append(groups[len(groups)-1], s)
+			continue
+		}
+		groups = append(groups, []span{s})
+	}
+
+	var rep Report
+	for _, g := range groups {
+		if len(g) >= 2 {
+			rep.add(nil, g, size)
+		}
+	}
+	return rep
+}
+
+// Like Analyze, for slices of a known array: the slices within the capacity of array
+// share its backing array, even when their own capacities do not overlap.
+// The array is drawn first in the diagram, but overlaps and clobbers are only reported
+// between the other slices.
+func Within[T any](array Named[T], named ...Named[T]) Report {
+	arr := spansOf([]Named[T]{array})
+	if len(arr) == 0 {
+		return Analyze(named...)
+	}
+	var inside, outside []Named[T]
+	for _, n := range named {
+		if s := spansOf([]Named[T]{n}); len(s) == 1 && s[0].start >= arr[0].start && s[0].cap <= arr[0].cap {
+			inside = append(inside, n)
+		} else {
+			outside = append(outside, n)
+		}
+	}
+	rep := Analyze(outside...)
+	if spans := spansOf(inside); len(spans) >= 2 {
+		slices.SortStableFunc(spans, func(a, b span) int { return cmpUintptr(a.start, b.start) })
+		rep.add(&arr[0], spans, unsafe.Sizeof(*new(T)))
+	}
+	return rep
+}
+
+// Return the slices as address ranges, skipping those that cannot share anything.
+func spansOf[T any](named []Named[T]) []span {
+	size := unsafe.Sizeof(*new(T))
+	var spans []span
+	for _, n := range named {
+		if size == 0 || cap(n.S) == 0 {
+			continue
+		}
+		start := uintptr(unsafe.Pointer(unsafe.SliceData(n.S)))
+		spans = append(spans, span{
+			name:  n.Name,
+			start: start,
+			end:   start + uintptr(len(n.S))*size,
+			cap:   start + uintptr(cap(n.S))*size,
+		})
+	}
+	return spans
+}
+
+// Add a group of slices sorted by address, with their overlaps and clobbers.
+// A non-nil array is drawn first and sets the start of the indexes.
+func (rep *Report) add(array *span, g []span, size uintptr) {
+	base := g[0].start
+	if array != nil {
+		base = array.start
+	}
+	idx := func(addr uintptr) int { return int((addr - base) / size) }
+	var group Group
+	if array != nil {
+		group.Slices = append(group.Slices, Layout{Name: array.name, Start: 0, End: idx(array.end), CapEnd: idx(array.cap)})
+	}
+	for _, s := range g {
+		group.Slices = append(group.Slices, Layout{Name: s.name, Start: idx(s.start), End: idx(s.end), CapEnd: idx(s.cap)})
+	}
+	rep.Groups = append(rep.Groups, group)
+
+	for i, a := range g {
+		for j, b := range g {
+			if j > i {
+				rep.addOverlap(a, b, idx)
+			}
+			if i != j {
+				rep.addClobber(a, b, idx)
+			}
+		}
+	}
+}
+
+// Add the elements that a and b both see, if any.
+func (rep *Report) addOverlap(a, b span, idx func(uintptr) int) {
+	if lo, hi := max(a.start, b.start), min(a.end, b.end); lo < hi {
+		rep.Overlaps = append(rep.Overlaps, Overlap{
+			A: a.name, ALo: idx(lo) - idx(a.start), AHi: idx(hi) - idx(a.start),
+			B: b.name, BLo: idx(lo) - idx(b.start), BHi: idx(hi) - idx(b.start),
+		})
+	}
+}
+
+// Add the elements of b that an append to a would overwrite, if any.
+// An append to a writes to [a.end, a.cap).
+func (rep *Report) addClobber(a, b span, idx func(uintptr) int) {
+	if lo, hi := max(a.end, b.start), min(a.cap, b.end); lo < hi {
+		rep.Clobbers = append(rep.Clobbers, Clobber{
+			Appender: a.name, Victim: b.name,
+			After: idx(lo) - idx(a.end) + 1,
+			Lo:    idx(lo) - idx(b.start), Hi: idx(hi) - idx(b.start),
+		})
+	}
+}
+
+// Return the end of the capacity of the slices of a group.
+func groupEnd(g []span) uintptr {
+	end := uintptr(0)
+	for _, s := range g {
+		end = max(end, s.cap)
+	}
+	return end
+}
+
+// Compare two addresses.
+func cmpUintptr(a, b uintptr) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Print the report: a diagram of each backing array, then the overlaps and the clobbers.
+//
+//	backing array of sliceM, subM1
+//	  sliceM  [####]  len 4, cap 4
+//	  subM1   [##..]  len 2, cap 4
+//	overlap: sliceM[0:2] is subM1[0:2]
+//	warning: append to subM1 overwrites sliceM[2:4] from value 1 on
+func (r Report) String() string {
+	if len(r.Groups) == 0 {
+		return "no shared backing array\n"
+	}
+	var sb strings.Builder
+	for _, g := range r.Groups {
+		width, size, names := 0, 0, make([]string, len(g.Slices))
+		for i, l := range g.Slices {
+			width = max(width, len(l.Name))
+			size = max(size, l.CapEnd)
+			names[i] = l.Name
+		}
+		fmt.Fprintf(&sb, "backing array of %s\n", strings.Join(names, ", "))
+		for _, l := range g.Slices {
+			// '#' visible, '.' spare capacity, ' ' outside the slice
+			cells := make([]string, size)
+			for k := range cells {
+				switch {
+				case k < l.Start, k >= l.CapEnd:
+					cells[k] = " "
+				case k < l.End:
+					cells[k] = "#"
+				default:
+					cells[k] = "."
+				}
+			}
+			fmt.Fprintf(&sb, "  %-*s  [%s]  len %d, cap %d\n", width, l.Name, strings.Join(cells, ""), l.End-l.Start, l.CapEnd-l.Start)
+		}
+	}
+	for _, o := range r.Overlaps {
+		fmt.Fprintf(&sb, "overlap: %s\n", o)
+	}
+	for _, c := range r.Clobbers {
+		fmt.Fprintf(&sb, "warning: %s\n", c)
+	}
+	return sb.String()
+}
@@ -0,0 +1,123 @@
+package alias_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maevadevs/Go-Developer-Advanced/Composite-Types/src/alias"
+)
+
+// A TB that records the errors instead of failing the test.
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAnalyze(t *testing.T) {
+	s := make([]int, 4, 6)
+	rep := alias.Analyze(alias.Of("s", s), alias.Of("head", s[:2]), alias.Of("mid", s[1:3:3]))
+	want := "backing array of s, head, mid\n" +
+		"  s     [####..]  len 4, cap 6\n" +
+		"  head  [##....]  len 2, cap 6\n" +
+		"  mid   [ ##   ]  len 2, cap 2\n" +
+		"overlap: s[0:2] is head[0:2]\n" +
+		"overlap: s[1:3] is mid[0:2]\n" +
+		"overlap: head[1:2] is mid[0:1]\n" +
+		"warning: append to head overwrites s[2:4] from value 1 on\n" +
+		"warning: append to head overwrites mid[1:2] from value 1 on\n"
+	if got := rep.String(); got != want {
+		t.Errorf("Analyze:\n%s\nwant:\n%s", got, want)
+	}
+
+	// Separate arrays, empty and zero-size slices share nothing
+	a, b := make([]int, 2), make([]int, 2)
+	for _, rep := range []alias.Report{
+		alias.Analyze(alias.Of("a", a), alias.Of("b", b)),
+		alias.Analyze(alias.Of("a", a[:0:0]), alias.Of("b", a[:0:0])),
+		alias.Analyze(alias.Of("e", make([]struct{}, 2)), alias.Of("f", make([]struct{}, 2))),
+	} {
+		if rep.String() != "no shared backing array\n" {
+			t.Errorf("report of unrelated slices:\n%s", rep)
+		}
+	}
+}
+
+func TestWithin(t *testing.T) {
+	s := make([]int, 4)
+	lo, hi := s[0:2:2], s[2:4]
+	// The capacities do not overlap: Analyze cannot tell that the slices share s
+	if rep := alias.Analyze(alias.Of("lo", lo), alias.Of("hi", hi)); len(rep.Groups) != 0 {
+		t.Errorf("Analyze of apart slices:\n%s", rep)
+	}
+
+	other := make([]int, 3)
+	rep := alias.Within(alias.Of("s", s), alias.Of("lo", lo), alias.Of("hi", hi), alias.Of("other", other), alias.Of("o2", other[1:]))
+	want := []alias.Group{
+		{Slices: []alias.Layout{{Name: "other", Start: 0, End: 3, CapEnd: 3}, {Name: "o2", Start: 1, End: 3, CapEnd: 3}}},
+		{Slices: []alias.Layout{{Name: "s", Start: 0, End: 4, CapEnd: 4}, {Name: "lo", Start: 0, End: 2, CapEnd: 2}, {Name: "hi", Start: 2, End: 4, CapEnd: 4}}},
+	}
+	if !slices.EqualFunc(rep.Groups, want, func(a, b alias.Group) bool { return slices.Equal(a.Slices, b.Slices) }) {
+		t.Errorf("Within groups = %+v, want %+v", rep.Groups, want)
+	}
+	if len(rep.Overlaps) != 1 || rep.Overlaps[0].A != "other" || len(rep.Clobbers) != 0 {
+		t.Errorf("Within: overlaps %v, clobbers %v, want only other and o2", rep.Overlaps, rep.Clobbers)
+	}
+
+	// A slice that overlaps the others is still checked within the array
+	rep = alias.Within(alias.Of("s", s), alias.Of("lo", lo), alias.Of("all", s[1:3]))
+	if len(rep.Overlaps) != 1 || rep.Overlaps[0].String() != "lo[1:2] is all[0:1]" {
+		t.Errorf("Within overlaps = %v", rep.Overlaps)
+	}
+	if len(rep.Clobbers) != 0 {
+		t.Errorf("Within clobbers = %v, want none", rep.Clobbers)
+	}
+}
+
+func TestAssertNoOverlap(t *testing.T) {
+	s := []int{1, 2, 3, 4}
+	tests := []struct {
+		name  string
+		named []alias.Named[int]
+		want  []string
+	}{
+		{"disjoint halves", []alias.Named[int]{alias.Of("head", s[:2]), alias.Of("tail", s[2:])}, nil},
+		{"copies", []alias.Named[int]{alias.Of("a", slices.Clone(s)), alias.Of("b", slices.Clone(s))}, nil},
+		{"overlapping", []alias.Named[int]{alias.Of("a", s[:3]), alias.Of("b", s[1:])},
+			[]string{"slices share elements: a[1:3] is b[0:2]"}},
+	}
+	for _, tt := range tests {
+		var r recorder
+		alias.AssertNoOverlap(&r, tt.named...)
+		if !slices.Equal(r.errors, tt.want) {
+			t.Errorf("%s: errors %q, want %q", tt.name, r.errors, tt.want)
+		}
+	}
+}
+
+func TestAssertAppendSafe(t *testing.T) {
+	s := []int{1, 2, 3, 4}
+	tests := []struct {
+		name  string
+		named []alias.Named[int]
+		want  []string
+	}{
+		{"full slice expression", []alias.Named[int]{alias.Of("head", s[:2:2]), alias.Of("tail", s[2:])}, nil},
+		{"spare capacity", []alias.Named[int]{alias.Of("head", s[:2]), alias.Of("tail", s[2:])},
+			[]string{"unsafe append: append to head overwrites tail[0:2] from value 1 on"}},
+		{"gap before the victim", []alias.Named[int]{alias.Of("head", s[:1]), alias.Of("last", s[3:])},
+			[]string{"unsafe append: append to head overwrites last[0:1] from value 3 on"}},
+	}
+	for _, tt := range tests {
+		var r recorder
+		alias.AssertAppendSafe(&r, tt.named...)
+		if !slices.Equal(r.errors, tt.want) {
+			t.Errorf("%s: errors %q, want %q", tt.name, r.errors, tt.want)
+		}
+	}
+}
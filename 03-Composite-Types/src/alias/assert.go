@@ -0,0 +1,29 @@
+package alias
+
+// The methods of testing.TB used by the assertions, so that this package does not import testing.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// Report an error for every pair of slices that see the same elements.
+//
+//	func TestSplit(t *testing.T) {
+//		head, tail := split(data)
+//		alias.AssertNoOverlap(t, alias.Of("head", head), alias.Of("tail", tail))
+//	}
+func AssertNoOverlap[T any](t TB, named ...Named[T]) {
+	t.Helper()
+	for _, o := range Analyze(named...).Overlaps {
+		t.Errorf("slices share elements: %s", o)
+	}
+}
+
+// Report an error for every append that would overwrite the elements of another slice.
+// Full slice expressions, s[lo:hi:hi], make subslices safe to append to.
+func AssertAppendSafe[T any](t TB, named ...Named[T]) {
+	t.Helper()
+	for _, c := range Analyze(named...).Clobbers {
+		t.Errorf("unsafe append: %s", c)
+	}
+}
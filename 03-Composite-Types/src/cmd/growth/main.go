@@ -0,0 +1,51 @@
+// Command growth traces the capacity of a slice while appending values one by one.
+//
+//	go run ./src/cmd/growth -n 5000 -size 8
+//	go run ./src/cmd/growth -n 100000 -size 24 -svg growth.svg
+//
+// By default, only the appends that allocated a new backing array are listed.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/maevadevs/Go-Developer-Advanced/Composite-Types/src/growth"
+)
+
+// This is the main entry of the application.
+func main() {
+	n := flag.Int("n", 5000, "number of values to append")
+	size := flag.Int("size", 8, "size of an element in bytes")
+	all := flag.Bool("all", false, "list every append, not only the reallocations")
+	svg := flag.String("svg", "", "also write an SVG chart of the growth factor to this file")
+	flag.Parse()
+	if *n < 0 || *size < 0 {
+		log.Fatal("-n and -size cannot be negative")
+	}
+
+	steps := growth.SimulateSize(*size, *n)
+	shown := steps
+	if !*all {
+		shown = growth.Reallocs(steps)
+	}
+	if err := growth.WriteTable(os.Stdout, shown); err != nil {
+		log.Fatal(err)
+	}
+
+	if *svg != "" {
+		fl, err := os.Create(*svg)
+		if err != nil {
+			log.Fatal(err)
+		}
+		title := fmt.Sprintf("Slice growth: %d appends of %d-byte elements", *n, *size)
+		if err := growth.WriteSVG(fl, steps, title); err != nil {
+			log.Fatal(err)
+		}
+		if err := fl.Close(); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
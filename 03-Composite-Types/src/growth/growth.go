@@ -0,0 +1,124 @@
+// Package growth records how the capacity of a slice grows as values are appended.
+//
+// When append runs out of capacity, the runtime allocates a larger backing array
+// and copies the elements. The new capacity roughly doubles while the old one is below 256,
+// then the growth factor slowly decreases toward 1.25. The runtime also rounds
+// the allocation up to a size class of the memory allocator, so the exact capacities
+// depend on the size of the elements.
+//
+// A reallocation is detected by comparing the address of the backing array before and after
+// each append. Zero-size elements all share one address: appending them never allocates,
+// even when their capacity grows.
+package growth
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// Below this capacity, append doubles the capacity. Above, growth slows toward 1.25.
+const Threshold = 256
+
+// The state of a slice after one append.
+type Step struct {
+	// Number of the append, starting at 1
+	Append int `json:"append"`
+	// Length and capacity before and after the append
+	OldLen int `json:"old_len"`
+	OldCap int `json:"old_cap"`
+	Len    int `json:"len"`
+	Cap    int `json:"cap"`
+	// Whether append allocated a new backing array
+	Realloc bool `json:"realloc"`
+	// Size of the backing array in bytes
+	Bytes int `json:"bytes"`
+}
+
+// Return Cap / OldCap, or 0 for the first allocation.
+func (s Step) Factor() float64 {
+	if s.OldCap == 0 {
+		return 0
+	}
+	return float64(s.Cap) / float64(s.OldCap)
+}
+
+// Wrap a slice and record every append.
+// The zero value wraps a nil slice and is ready to use.
+type Tracer[T any] struct {
+	s     []T
+	steps []Step
+}
+
+// Start tracing a slice. Appends go to s, and possibly to the elements after len(s).
+func New[T any](s []T) *Tracer[T] {
+	return &Tracer[T]{s: s}
+}
+
+// Append values like the built-in append, as a single step.
+func (t *Tracer[T]) Append(vals ...T) {
+	oldLen, oldCap, oldArray := len(t.s), cap(t.s), unsafe.SliceData(t.s)
+	t.s = append(t.s, vals...)
+	t.steps = append(t.steps, Step{
+		Append:  len(t.steps) + 1,
+		OldLen:  oldLen,
+		OldCap:  oldCap,
+		Len:     len(t.s),
+		Cap:     cap(t.s),
+		Realloc: unsafe.SliceData(t.s) != oldArray,
+		Bytes:   cap(t.s) * int(unsafe.Sizeof(*new(T))),
+	})
+}
+
+// Return the traced slice.
+func (t *Tracer[T]) Slice() []T {
+	return t.s
+}
+
+// Return the recorded steps.
+func (t *Tracer[T]) Steps() []Step {
+	return t.steps
+}
+
+// Append n zero values of type T one by one to a nil slice, and return the steps.
+func Simulate[T any](n int) []Step {
+	var t Tracer[T]
+	var zero T
+	for range n {
+		t.Append(zero)
+	}
+	return t.steps
+}
+
+// Like Simulate, for elements of any size in bytes, chosen at run time.
+// The elements are byte arrays: [size]byte.
+func SimulateSize(size, n int) []Step {
+	typ := reflect.ArrayOf(size, reflect.TypeFor[byte]())
+	s := reflect.MakeSlice(reflect.SliceOf(typ), 0, 0)
+	zero := reflect.Zero(typ)
+	steps := make([]Step, 0, n)
+	for i := range n {
+		oldLen, oldCap, oldArray := s.Len(), s.Cap(), s.Pointer()
+		s = reflect.Append(s, zero)
+		steps = append(steps, Step{
+			Append:  i + 1,
+			OldLen:  oldLen,
+			OldCap:  oldCap,
+			Len:     s.Len(),
+			Cap:     s.Cap(),
+			Realloc: s.Pointer() != oldArray,
+			Bytes:   s.Cap() * size,
+		})
+	}
+	return steps
+}
+
+// Keep only the steps that allocated a new backing array.
+func Reallocs(steps []Step) []Step {
+	var out []Step
+	for _, s := range steps {
+		if s.Realloc {
+			out = append(out, s)
+		}
+	}
+	return out
+}
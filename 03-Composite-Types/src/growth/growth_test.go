@@ -0,0 +1,87 @@
+package growth_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/maevadevs/Go-Developer-Advanced/Composite-Types/src/growth"
+)
+
+// Check the invariants of the steps of a slice of non-zero-size elements:
+// the capacity never shrinks, and it changes exactly when a reallocation is reported.
+func checkSteps(t *testing.T, name string, steps []growth.Step, size int) {
+	t.Helper()
+	for i, s := range steps {
+		switch {
+		case s.Append != i+1 || (i > 0 && s.OldLen != steps[i-1].Len) || (i > 0 && s.OldCap != steps[i-1].Cap):
+			t.Errorf("%s: step %+v does not follow %+v", name, s, steps[i-1])
+		case s.Cap < s.OldCap || s.Cap < s.Len:
+			t.Errorf("%s: step %+v shrinks the capacity", name, s)
+		case s.Realloc != (s.Cap != s.OldCap):
+			t.Errorf("%s: step %+v reports a reallocation without a change of capacity, or the opposite", name, s)
+		case s.Bytes != s.Cap*size:
+			t.Errorf("%s: step %+v: %d bytes, want %d", name, s, s.Bytes, s.Cap*size)
+		}
+	}
+}
+
+func TestTracer(t *testing.T) {
+	tr := growth.New(make([]int, 0, 2))
+	tr.Append(1, 2)
+	tr.Append(3)
+	tr.Append(4)
+	tr.Append(5, 6, 7, 8, 9)
+	steps := tr.Steps()
+	checkSteps(t, "Tracer", steps, 8)
+	lens := []int{2, 3, 4, 9}
+	for i, s := range steps {
+		if s.Len != lens[i] {
+			t.Errorf("step %d: len %d, want %d", i+1, s.Len, lens[i])
+		}
+	}
+	// Appends within the capacity do not reallocate, appends beyond it always do
+	if steps[0].Realloc || !steps[1].Realloc || !steps[3].Realloc {
+		t.Errorf("Steps = %+v", steps)
+	}
+	if got := tr.Slice(); !slices.Equal(got, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("Slice = %v", got)
+	}
+	if f := (growth.Step{OldCap: 4, Cap: 10}).Factor(); f != 2.5 {
+		t.Errorf("Factor = %v, want 2.5", f)
+	}
+	if f := (growth.Step{Cap: 1}).Factor(); f != 0 {
+		t.Errorf("Factor of the first allocation = %v, want 0", f)
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	for name, steps := range map[string][]growth.Step{
+		"int64":   growth.Simulate[int64](2000),
+		"[8]byte": growth.SimulateSize(8, 2000),
+	} {
+		checkSteps(t, name, steps, 8)
+		reallocs := growth.Reallocs(steps)
+		if len(reallocs) == 0 || reallocs[0].OldCap != 0 {
+			t.Fatalf("%s: reallocations %+v", name, reallocs)
+		}
+	}
+	// The same element size grows the same way, whatever the type
+	if a, b := growth.Reallocs(growth.Simulate[int64](2000)), growth.Reallocs(growth.SimulateSize(8, 2000)); !slices.Equal(a, b) {
+		t.Errorf("int64 and [8]byte grow differently:\n%+v\n%+v", a, b)
+	}
+}
+
+func TestZeroSize(t *testing.T) {
+	// All zero-size backing arrays have the same address: the capacity grows without any allocation
+	for name, steps := range map[string][]growth.Step{
+		"struct{}": growth.Simulate[struct{}](20),
+		"[0]byte":  growth.SimulateSize(0, 20),
+	} {
+		// The first step of Simulate leaves the nil slice for the shared address
+		for _, s := range steps[1:] {
+			if s.Realloc || s.Bytes != 0 || s.Cap < s.OldCap {
+				t.Errorf("%s: step %+v", name, s)
+			}
+		}
+	}
+}
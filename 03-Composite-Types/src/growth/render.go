@@ -0,0 +1,117 @@
+package growth
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"text/tabwriter"
+)
+
+// Write the steps as a table. The regime tells which growth rule applied:
+// double below Threshold, smooth above.
+func WriteTable(w io.Writer, steps []Step) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "APPEND\tLEN\tOLD CAP\tCAP\tFACTOR\tBYTES\tREALLOC\tREGIME\t")
+	for _, s := range steps {
+		factor, regime := "-", "-"
+		if s.Realloc && s.OldCap > 0 {
+			factor = fmt.Sprintf("%.3f", s.Factor())
+			regime = "double"
+			if s.OldCap >= Threshold {
+				regime = "smooth"
+			}
+		}
+		realloc := ""
+		if s.Realloc {
+			realloc = "yes"
+		}
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%d\t%s\t%d\t%s\t%s\t\n",
+			s.Append, s.Len, s.OldCap, s.Cap, factor, s.Bytes, realloc, regime)
+	}
+	return tw.Flush()
+}
+
+// Size of the SVG chart and of its margins.
+const (
+	svgWidth, svgHeight = 720, 400
+	marginLeft          = 60
+	marginRight         = 20
+	marginTop           = 30
+	marginBottom        = 50
+)
+
+// Write an SVG chart of the growth factor of every reallocation against the old capacity,
+// on a logarithmic axis, with the threshold and the limits 2 and 1.25 marked.
+func WriteSVG(w io.Writer, steps []Step, title string) error {
+	var points []Step
+	for _, s := range Reallocs(steps) {
+		if s.OldCap > 0 {
+			points = append(points, s)
+		}
+	}
+	maxCap := Threshold * 4
+	maxFactor := 2.0
+	for _, p := range points {
+		maxCap = max(maxCap, p.OldCap)
+		maxFactor = max(maxFactor, p.Factor())
+	}
+	// x: log2 of the old capacity, y: growth factor from 1
+	xMax := math.Ceil(math.Log2(float64(maxCap)))
+	yMax := math.Ceil(maxFactor*4) / 4
+	x := func(c int) float64 {
+		return marginLeft + math.Log2(float64(c))/xMax*(svgWidth-marginLeft-marginRight)
+	}
+	y := func(f float64) float64 {
+		return svgHeight - marginBottom - (f-1)/(yMax-1)*(svgHeight-marginTop-marginBottom)
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="11">`+"\n", svgWidth, svgHeight)
+	fmt.Fprintf(bw, `  <rect width="%d" height="%d" fill="white"/>`+"\n", svgWidth, svgHeight)
+	fmt.Fprintf(bw, `  <text x="%d" y="18" font-size="14">%s</text>`+"\n", marginLeft, escapeXML(title))
+
+	// Axes, with a tick at every power of 2 and every 0.25
+	fmt.Fprintf(bw, `  <line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="black"/>`+"\n", marginLeft, y(1), svgWidth-marginRight, y(1))
+	fmt.Fprintf(bw, `  <line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="black"/>`+"\n", marginLeft, y(1), marginLeft, y(yMax))
+	for e := 0; e <= int(xMax); e += 2 {
+		c := 1 << e
+		fmt.Fprintf(bw, `  <text x="%.1f" y="%.1f" text-anchor="middle">%d</text>`+"\n", x(c), y(1)+15, c)
+	}
+	fmt.Fprintf(bw, `  <text x="%d" y="%d" text-anchor="middle">old capacity (log scale)</text>`+"\n", (svgWidth+marginLeft)/2, svgHeight-10)
+	for f := 1.0; f <= yMax; f += 0.25 {
+		fmt.Fprintf(bw, `  <text x="%d" y="%.1f" text-anchor="end">%.2f</text>`+"\n", marginLeft-5, y(f)+4, f)
+	}
+	fmt.Fprintf(bw, `  <text x="15" y="%d" transform="rotate(-90 15 %d)" text-anchor="middle">growth factor</text>`+"\n", svgHeight/2, svgHeight/2)
+
+	// The regimes of the growth formula
+	for _, f := range []float64{2, 1.25} {
+		fmt.Fprintf(bw, `  <line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="gray" stroke-dasharray="4"/>`+"\n", marginLeft, y(f), svgWidth-marginRight, y(f))
+	}
+	fmt.Fprintf(bw, `  <line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="red" stroke-dasharray="4"/>`+"\n", x(Threshold), y(1), x(Threshold), y(yMax))
+	fmt.Fprintf(bw, `  <text x="%.1f" y="%.1f" fill="red">threshold %d</text>`+"\n", x(Threshold)+4, y(yMax)+12, Threshold)
+
+	// The reallocations
+	if len(points) > 0 {
+		fmt.Fprint(bw, `  <polyline fill="none" stroke="steelblue" points="`)
+		for i, p := range points {
+			if i > 0 {
+				fmt.Fprint(bw, " ")
+			}
+			fmt.Fprintf(bw, "%.1f,%.1f", x(p.OldCap), y(p.Factor()))
+		}
+		fmt.Fprintln(bw, `"/>`)
+		for _, p := range points {
+			fmt.Fprintf(bw, `  <circle cx="%.1f" cy="%.1f" r="3" fill="steelblue"><title>cap %d → %d (×%.3f)</title></circle>`+"\n",
+				x(p.OldCap), y(p.Factor()), p.OldCap, p.Cap, p.Factor())
+		}
+	}
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
+
+// Escape the characters that have a meaning in XML text.
+func escapeXML(s string) string {
+	return strings.NewReplacer("<", "&lt;", ">", "&gt;", "&", "&amp;").Replace(s)
+}
@@ -1,10 +1,19 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"maps"
+	"os"
 	"slices"
 	"strings"
+
+	"github.com/maevadevs/Go-Developer-Advanced/Composite-Types/src/alias"
+	"github.com/maevadevs/Go-Developer-Advanced/Composite-Types/src/growth"
+	"github.com/maevadevs/Go-Developer-Advanced/Composite-Types/src/matrix"
+	"github.com/maevadevs/Go-Developer-Advanced/Composite-Types/src/set"
+	"github.com/maevadevs/Go-Developer-Advanced/Composite-Types/src/sparse"
+	"github.com/maevadevs/Go-Developer-Advanced/Composite-Types/src/ustr"
 )
 
 // This is the main entry of the application.
@@ -135,6 +144,70 @@ func main() {
 	fmt.Println("sliceC =", sliceC)
 	fmt.Println()
 
+	// Contiguous Matrices
+	// -------------------
+	fmt.Println("Contiguous Matrices:")
+	fmt.Println("--------------------")
+
+	// The same values, copied into a single backing slice
+	// All the rows of sliceC have 4 elements: FromRows cannot fail
+	matC, _ := matrix.FromRows(sliceC)
+	fmt.Println("matC.Data() =", matC.Data())
+	fmt.Print("matC =\n", matC)
+
+	// Rows and columns are views: writing to them writes to matC
+	colC := matC.Col(1)
+	colC.Set(0, 20)
+	fmt.Println("matC.Row(0) =", matC.Row(0).Slice())
+	fmt.Println("matC.Col(1) =", colC.Slice())
+
+	// Multiplying by the identity gives the matrix back
+	prodC, _ := matrix.Mul(matC.T(), matrix.Identity[int](4))
+	fmt.Print("matC.T() × I =\n", prodC)
+
+	// Determinant and inverse are defined for float matrices only
+	matF, _ := matrix.FromRows([][]float64{{4, 7}, {2, 6}})
+	detF, _ := matrix.Det(matF)
+	invF, _ := matrix.Inverse(matF)
+	fmt.Println("det(matF) =", detF)
+	fmt.Print("matF⁻¹ =\n", invF)
+	fmt.Println()
+
+	// Sparse Storage
+	// --------------
+	fmt.Println("Sparse Storage:")
+	fmt.Println("---------------")
+
+	// The sparse literal sliceB, stored by its non-zero elements only
+	vecB := sparse.IndexFromDense(sliceB)
+	mapB := sparse.MapFromDense(sliceB)
+	mapB.Set(2, 3)
+	mapB.Set(5, 0)
+	sumB, _ := sparse.Add[int](vecB, mapB)
+	fmt.Println("vecB =", sparse.Dense[int](vecB), "=> stored:", vecB.NNZ())
+	fmt.Println("mapB =", sparse.Dense[int](mapB), "=> stored:", mapB.NNZ())
+	fmt.Println("vecB + mapB =", sparse.Dense[int](sumB))
+
+	// A matrix built entry by entry in COO, then compressed to CSR to compute
+	cooC := sparse.NewCOO[int](4, 4)
+	cooC.Append(0, 0, 1)
+	cooC.Append(2, 3, 2)
+	cooC.Append(2, 3, 1) // Duplicates are added together
+	csrC := cooC.ToCSR()
+	mulC, _ := csrC.MulDense(matC)
+	fmt.Print("csrC =\n", csrC.Dense())
+	fmt.Print("csrC × matC =\n", mulC)
+
+	// The sparse forms only pay off when most elements are zero
+	diagD := sparse.NewCOO[float64](1000, 1000)
+	for i := range 1000 {
+		diagD.Append(i, i, 1)
+	}
+	if err := sparse.WriteUsage(os.Stdout, vecB.Usage(), mapB.Usage(), diagD.Usage(), diagD.ToCSR().Usage()); err != nil {
+		fmt.Println("Error:", err)
+	}
+	fmt.Println()
+
 	// Slice Access
 	// ------------
 	fmt.Println("Slice Access:")
@@ -251,6 +324,27 @@ func main() {
 	fmt.Println(xSlice, "\t", len(xSlice), "\t", cap(xSlice))
 	fmt.Println()
 
+	// Tracing Slice Capacity Growth
+	// -----------------------------
+	fmt.Println("Tracing Slice Capacity Growth:")
+	fmt.Println("------------------------------")
+
+	// The same appends, recorded instead of printed by hand
+	xTracer := growth.New[int](nil)
+	for _, v := range []int{10, 20, 30, 40, 50} {
+		xTracer.Append(v)
+	}
+	fmt.Println("xTracer.Slice() =", xTracer.Slice())
+	if err := growth.WriteTable(os.Stdout, xTracer.Steps()); err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	// Only the reallocations, for 2000 appends: doubling up to 256, then slower growth
+	if err := growth.WriteTable(os.Stdout, growth.Reallocs(growth.Simulate[int](2000))); err != nil {
+		fmt.Println("Error:", err)
+	}
+	fmt.Println()
+
 	// Declaring a slice using make()
 	// ------------------------------
 	fmt.Println("Declaring a slice using make():")
@@ -370,6 +464,44 @@ func main() {
 	fmt.Println("subN2 =", subN2)
 	fmt.Println()
 
+	// Detecting Aliased Slices
+	// ------------------------
+	fmt.Println("Detecting Aliased Slices:")
+	fmt.Println("-------------------------")
+
+	// subM1 and subM2 still share the backing array of sliceM
+	fmt.Print(alias.Analyze(
+		alias.Of("sliceM", sliceM),
+		alias.Of("subM1", subM1),
+		alias.Of("subM2", subM2),
+	))
+	fmt.Println()
+
+	// The full slice expression limits subM5: appending to it reallocates
+	subM5 := sliceM[:2:2]
+	fmt.Print(alias.Analyze(
+		alias.Of("sliceM", sliceM),
+		alias.Of("subM5", subM5),
+	))
+	fmt.Println()
+
+	// Full slice expressions keep subM6 and subM7 apart: only sliceM shows they share its array
+	subM6, subM7 := sliceM[0:2:2], sliceM[2:4]
+	fmt.Print(alias.Within(
+		alias.Of("sliceM", sliceM),
+		alias.Of("subM6", subM6),
+		alias.Of("subM7", subM7),
+	))
+	fmt.Println()
+
+	// Every append above reallocated: subN1 and subN2 no longer share anything
+	fmt.Print(alias.Analyze(
+		alias.Of("sliceN", sliceN),
+		alias.Of("subN1", subN1),
+		alias.Of("subN2", subN2),
+	))
+	fmt.Println()
+
 	// Slicing Copy
 	// ------------
 	fmt.Println("Slicing copy:")
@@ -512,6 +644,25 @@ func main() {
 	fmt.Println("len(strC) =", len(strC))
 	fmt.Println()
 
+	// Unicode-Aware String Helpers
+	// ----------------------------
+	fmt.Println("Unicode-Aware String Helpers:")
+	fmt.Println("-----------------------------")
+
+	// Indexes count runes: 😊 is the rune at index 6
+	fmt.Printf("ustr.Substr(strC, 4, 7) = %q\n", ustr.Substr(strC, 4, 7))
+	// Flags and emoji with a skin tone are several runes, reversed as one
+	fmt.Println("ustr.Reverse(\"🇫🇷 👍🏽 é\") =", ustr.Reverse("🇫🇷 👍🏽 é"))
+	// Widths count terminal columns: 😊 and 日 take 2 columns each
+	fmt.Println("ustr.Width(strC) =", ustr.Width(strC), "ustr.Width(\"日本語\") =", ustr.Width("日本語"))
+	fmt.Printf("ustr.Truncate(strC, 7, \"…\") = %q\n", ustr.Truncate(strC, 7, "…"))
+	for _, word := range []string{"Go", "日本語", "😊😊"} {
+		fmt.Printf("|%s|%s|\n", ustr.PadRight(word, 8, ' '), ustr.PadLeft(word, 8, '.'))
+	}
+	// strB[4:7] cut 😊 in the middle: the bytes left are invalid UTF-8
+	fmt.Println("ustr.ValidateUTF8(strBSub2):", ustr.ValidateUTF8(strBSub2))
+	fmt.Println()
+
 	// Strings, runes, bytes type conversion
 	// -------------------------------------
 	fmt.Println("Strings, runes, bytes type conversion:")
@@ -697,6 +848,29 @@ func main() {
 	fmt.Println("2000 in intSet2?", inIntSet2)
 	fmt.Println()
 
+	// Using a Generic Set
+	// -------------------
+	fmt.Println("Using a Generic Set:")
+	fmt.Println("--------------------")
+
+	// The same values, without a dummy bool or string
+	valSet := set.New(vals...)
+	fmt.Println("valSet =", valSet, "len =", valSet.Len())
+	fmt.Println("5 in valSet?", valSet.Has(5))
+	fmt.Println("2000 in valSet?", valSet.Has(2000))
+
+	evens := set.New(2, 4, 6, 8, 10)
+	fmt.Println("evens =", evens)
+	fmt.Println("valSet ∪ evens =", valSet.Union(evens))
+	fmt.Println("valSet ∩ evens =", valSet.Intersect(evens))
+	fmt.Println("valSet \\ evens =", valSet.Difference(evens))
+	fmt.Println("valSet △ evens =", valSet.SymmetricDifference(evens))
+	fmt.Println("evens ⊆ valSet?", evens.IsSubset(valSet))
+	fmt.Println("Sorted slice:", set.Sorted(valSet))
+	valJSON, _ := json.Marshal(valSet)
+	fmt.Println("As JSON:", string(valJSON))
+	fmt.Println()
+
 	// Struct Definition and Declaration
 	// ---------------------------------
 	fmt.Println("Struct Definition and Declaration:")
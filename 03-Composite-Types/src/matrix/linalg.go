@@ -0,0 +1,100 @@
+package matrix
+
+import "fmt"
+
+// Return the determinant of a square matrix, by Gaussian elimination with partial pivoting.
+func Det[T Float](m *Matrix[T]) (T, error) {
+	if m.rows != m.cols {
+		return 0, fmt.Errorf("%w: %dx%d", ErrNotSquare, m.rows, m.cols)
+	}
+	n := m.rows
+	a := m.Clone().data
+	det := T(1)
+	for col := range n {
+		p := pivot(a, n, col)
+		if a[p*n+col] == 0 {
+			return 0, nil
+		}
+		if p != col {
+			swapRows(a, n, p, col)
+			det = -det
+		}
+		det *= a[col*n+col]
+		for r := col + 1; r < n; r++ {
+			f := a[r*n+col] / a[col*n+col]
+			for c := col; c < n; c++ {
+				a[r*n+c] -= f * a[col*n+c]
+			}
+		}
+	}
+	return det, nil
+}
+
+// Return the inverse of a square matrix, by Gauss-Jordan elimination with partial pivoting.
+// Only an exactly zero pivot is detected: a nearly singular matrix gives a very large inverse.
+func Inverse[T Float](m *Matrix[T]) (*Matrix[T], error) {
+	if m.rows != m.cols {
+		return nil, fmt.Errorf("%w: %dx%d", ErrNotSquare, m.rows, m.cols)
+	}
+	n := m.rows
+	a := m.Clone().data
+	inv := Identity[T](n)
+	b := inv.data
+	for col := range n {
+		p := pivot(a, n, col)
+		if a[p*n+col] == 0 {
+			return nil, ErrSingular
+		}
+		swapRows(a, n, p, col)
+		swapRows(b, n, p, col)
+
+		// Scale the pivot row to put a 1 on the diagonal, then clear the column in the other rows
+		d := a[col*n+col]
+		for c := range n {
+			a[col*n+c] /= d
+			b[col*n+c] /= d
+		}
+		for r := range n {
+			if r == col || a[r*n+col] == 0 {
+				continue
+			}
+			f := a[r*n+col]
+			for c := range n {
+				a[r*n+c] -= f * a[col*n+c]
+				b[r*n+c] -= f * b[col*n+c]
+			}
+		}
+	}
+	return inv, nil
+}
+
+// Return the row, from col down, with the largest absolute value in column col.
+// Dividing by the largest pivot limits the rounding errors.
+func pivot[T Float](a []T, n, col int) int {
+	best := col
+	for r := col + 1; r < n; r++ {
+		if abs(a[r*n+col]) > abs(a[best*n+col]) {
+			best = r
+		}
+	}
+	return best
+}
+
+// Swap rows i and j of an n x n matrix stored in a.
+func swapRows[T Number](a []T, n, i, j int) {
+	if i == j {
+		return
+	}
+	ri, rj := a[i*n:(i+1)*n], a[j*n:(j+1)*n]
+	for c := range ri {
+		ri[c], rj[c] = rj[c], ri[c]
+	}
+}
+
+// Return the absolute value of x.
+func abs[T Float](x T) T {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
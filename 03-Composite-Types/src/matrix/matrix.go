@@ -0,0 +1,247 @@
+// Package matrix implements dense matrices stored in a single slice.
+//
+// A [5][5]int has a fixed size, and a [][]int built with make is a slice of separate rows,
+// each with its own backing array. A Matrix keeps all its elements in one slice, row after row:
+// element (i, j) is at index i*cols + j. Rows and columns are views on that slice,
+// so reading or writing them does not copy anything.
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// The element types of a matrix.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// The element types for which a determinant and an inverse are computed.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Errors returned by the operations on matrices.
+var (
+	ErrShape     = errors.New("matrix: shapes do not match")
+	ErrNotSquare = errors.New("matrix: not a square matrix")
+	ErrSingular  = errors.New("matrix: singular matrix")
+)
+
+// A rows x cols matrix. The zero value is an empty 0x0 matrix.
+type Matrix[T Number] struct {
+	rows, cols int
+	data       []T
+}
+
+// Create a rows x cols matrix of zeros.
+func New[T Number](rows, cols int) *Matrix[T] {
+	if rows < 0 || cols < 0 {
+		panic(fmt.Sprintf("matrix: negative size %dx%d", rows, cols))
+	}
+	return &Matrix[T]{rows: rows, cols: cols, data: make([]T, rows*cols)}
+}
+
+// Create a matrix from a slice of rows, which are copied.
+// All the rows must have the same length.
+func FromRows[T Number](rows [][]T) (*Matrix[T], error) {
+	cols := 0
+	if len(rows) > 0 {
+		cols = len(rows[0])
+	}
+	m := New[T](len(rows), cols)
+	for i, r := range rows {
+		if len(r) != cols {
+			return nil, fmt.Errorf("%w: row %d has %d elements, row 0 has %d", ErrShape, i, len(r), cols)
+		}
+		copy(m.data[i*cols:], r)
+	}
+	return m, nil
+}
+
+// Create the n x n identity matrix.
+func Identity[T Number](n int) *Matrix[T] {
+	m := New[T](n, n)
+	for i := range n {
+		m.data[i*n+i] = 1
+	}
+	return m
+}
+
+// Return the number of rows.
+func (m *Matrix[T]) Rows() int {
+	return m.rows
+}
+
+// Return the number of columns.
+func (m *Matrix[T]) Cols() int {
+	return m.cols
+}
+
+// Return the backing slice, row after row. Writing to it writes to the matrix.
+func (m *Matrix[T]) Data() []T {
+	return m.data
+}
+
+// Return the index of element (i, j) in the backing slice.
+func (m *Matrix[T]) index(i, j int) int {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		panic(fmt.Sprintf("matrix: index (%d, %d) out of range for %dx%d", i, j, m.rows, m.cols))
+	}
+	return i*m.cols + j
+}
+
+// Return element (i, j).
+func (m *Matrix[T]) At(i, j int) T {
+	return m.data[m.index(i, j)]
+}
+
+// Set element (i, j).
+func (m *Matrix[T]) Set(i, j int, v T) {
+	m.data[m.index(i, j)] = v
+}
+
+// Return a view of row i.
+func (m *Matrix[T]) Row(i int) Vector[T] {
+	// Not m.index(i, 0): a matrix with 0 columns still has rows
+	if i < 0 || i >= m.rows {
+		panic(fmt.Sprintf("matrix: row %d out of range for %dx%d", i, m.rows, m.cols))
+	}
+	start := i * m.cols
+	// The full slice expression keeps an append on the view away from the next row
+	return Vector[T]{data: m.data[start : start+m.cols : start+m.cols], n: m.cols, stride: 1}
+}
+
+// Return a view of column j.
+func (m *Matrix[T]) Col(j int) Vector[T] {
+	if j < 0 || j >= m.cols {
+		panic(fmt.Sprintf("matrix: column %d out of range for %dx%d", j, m.rows, m.cols))
+	}
+	if m.rows == 0 {
+		// A matrix with 0 rows has columns, but no elements to point at
+		return Vector[T]{stride: m.cols}
+	}
+	return Vector[T]{data: m.data[j:], n: m.rows, stride: m.cols}
+}
+
+// Return a copy of the matrix.
+func (m *Matrix[T]) Clone() *Matrix[T] {
+	c := New[T](m.rows, m.cols)
+	copy(c.data, m.data)
+	return c
+}
+
+// Return the transpose of the matrix, as a new matrix.
+func (m *Matrix[T]) T() *Matrix[T] {
+	t := New[T](m.cols, m.rows)
+	for i := range m.rows {
+		for j := range m.cols {
+			t.data[j*m.rows+i] = m.data[i*m.cols+j]
+		}
+	}
+	return t
+}
+
+// Report whether two matrices have the same shape and elements.
+func (m *Matrix[T]) Equal(o *Matrix[T]) bool {
+	if m.rows != o.rows || m.cols != o.cols {
+		return false
+	}
+	for k, v := range m.data {
+		if o.data[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Print one row per line, with the columns aligned.
+//
+//	[1 0 0]
+//	[0 1 0]
+//	[0 0 1]
+func (m *Matrix[T]) String() string {
+	cells := make([]string, len(m.data))
+	width := 0
+	for k, v := range m.data {
+		cells[k] = fmt.Sprint(v)
+		width = max(width, len(cells[k]))
+	}
+	var sb strings.Builder
+	for i := range m.rows {
+		sb.WriteByte('[')
+		for j := range m.cols {
+			if j > 0 {
+				sb.WriteByte(' ')
+			}
+			fmt.Fprintf(&sb, "%*s", width, cells[i*m.cols+j])
+		}
+		sb.WriteString("]\n")
+	}
+	return sb.String()
+}
+
+// A row or a column of a matrix. It shares the elements of the matrix.
+type Vector[T Number] struct {
+	data      []T
+	n, stride int
+}
+
+// Return the number of elements.
+func (v Vector[T]) Len() int {
+	return v.n
+}
+
+// Return the index of element i in the backing slice.
+func (v Vector[T]) index(i int) int {
+	if i < 0 || i >= v.n {
+		panic(fmt.Sprintf("matrix: index %d out of range for vector of length %d", i, v.n))
+	}
+	return i * v.stride
+}
+
+// Return element i.
+func (v Vector[T]) At(i int) T {
+	return v.data[v.index(i)]
+}
+
+// Set element i, in the matrix.
+func (v Vector[T]) Set(i int, x T) {
+	v.data[v.index(i)] = x
+}
+
+// Iterate over the indexes and the elements.
+func (v Vector[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range v.n {
+			if !yield(i, v.data[i*v.stride]) {
+				return
+			}
+		}
+	}
+}
+
+// Return a copy of the elements.
+func (v Vector[T]) Slice() []T {
+	s := make([]T, v.n)
+	for i, x := range v.All() {
+		s[i] = x
+	}
+	return s
+}
+
+// Return the dot product of two vectors of the same length.
+func Dot[T Number](a, b Vector[T]) (T, error) {
+	if a.n != b.n {
+		return 0, fmt.Errorf("%w: vectors of length %d and %d", ErrShape, a.n, b.n)
+	}
+	var sum T
+	for i := range a.n {
+		sum += a.data[i*a.stride] * b.data[i*b.stride]
+	}
+	return sum, nil
+}
@@ -0,0 +1,250 @@
+package matrix_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/maevadevs/Go-Developer-Advanced/Composite-Types/src/matrix"
+)
+
+// Return a rows x cols matrix of random values, the same for the same seed.
+func random(rows, cols int, seed uint64) *matrix.Matrix[int] {
+	r := rand.New(rand.NewPCG(seed, seed))
+	m := matrix.New[int](rows, cols)
+	for k := range m.Data() {
+		m.Data()[k] = r.IntN(21) - 10
+	}
+	return m
+}
+
+// Create a matrix from rows, or fail the test.
+func fromRows[T matrix.Number](t testing.TB, rows [][]T) *matrix.Matrix[T] {
+	t.Helper()
+	m, err := matrix.FromRows(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestMul(t *testing.T) {
+	// Shapes that do not fall on the block boundaries, and empty ones
+	shapes := [][3]int{{1, 1, 1}, {2, 3, 4}, {7, 5, 3}, {17, 33, 9}, {64, 64, 64}, {65, 130, 67}, {0, 3, 2}, {3, 0, 2}, {3, 2, 0}}
+	for _, sh := range shapes {
+		a, b := random(sh[0], sh[1], 1), random(sh[1], sh[2], 2)
+		want, err := matrix.MulNaive(a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want.Rows() != sh[0] || want.Cols() != sh[2] {
+			t.Fatalf("MulNaive of %v: %dx%d", sh, want.Rows(), want.Cols())
+		}
+		if got, err := matrix.Mul(a, b); err != nil || !got.Equal(want) {
+			t.Errorf("Mul of %v differs from MulNaive: %v", sh, err)
+		}
+		for _, block := range []int{0, 1, 2, 4, 16, 1000} {
+			if got, err := matrix.MulBlocked(a, b, block); err != nil || !got.Equal(want) {
+				t.Errorf("MulBlocked of %v with block %d differs from MulNaive: %v", sh, block, err)
+			}
+		}
+	}
+
+	a := fromRows(t, [][]int{{1, 2}, {3, 4}})
+	b := fromRows(t, [][]int{{5, 6}, {7, 8}})
+	if got, _ := matrix.Mul(a, b); !got.Equal(fromRows(t, [][]int{{19, 22}, {43, 50}})) {
+		t.Errorf("Mul =\n%v", got)
+	}
+	if got, _ := matrix.Mul(a, matrix.Identity[int](2)); !got.Equal(a) {
+		t.Errorf("Mul by identity =\n%v", got)
+	}
+	for _, mul := range []func(a, b *matrix.Matrix[int]) (*matrix.Matrix[int], error){matrix.Mul[int], matrix.MulNaive[int]} {
+		if _, err := mul(random(2, 3, 1), random(2, 3, 1)); !errors.Is(err, matrix.ErrShape) {
+			t.Errorf("2x3 × 2x3: err = %v, want ErrShape", err)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	m := fromRows(t, [][]int{{1, 2, 3}, {4, 5, 6}})
+	want := fromRows(t, [][]int{{1, 4}, {2, 5}, {3, 6}})
+	if got := m.T(); !got.Equal(want) {
+		t.Errorf("T() =\n%v", got)
+	}
+	if got := m.T().T(); !got.Equal(m) {
+		t.Errorf("T().T() =\n%v", got)
+	}
+	// (ab)ᵀ = bᵀaᵀ
+	a, b := random(4, 6, 3), random(6, 5, 4)
+	ab, _ := matrix.Mul(a, b)
+	btat, _ := matrix.Mul(b.T(), a.T())
+	if !ab.T().Equal(btat) {
+		t.Error("(ab)ᵀ != bᵀaᵀ")
+	}
+}
+
+func TestRowCol(t *testing.T) {
+	m := fromRows(t, [][]int{{1, 2, 3}, {4, 5, 6}})
+	if got := m.Row(1).Slice(); fmt.Sprint(got) != "[4 5 6]" {
+		t.Errorf("Row(1) = %v", got)
+	}
+	if got := m.Col(2).Slice(); fmt.Sprint(got) != "[3 6]" {
+		t.Errorf("Col(2) = %v", got)
+	}
+	// Views write to the matrix
+	m.Col(0).Set(1, 40)
+	if m.At(1, 0) != 40 {
+		t.Errorf("Col(0).Set(1, 40): At(1, 0) = %d", m.At(1, 0))
+	}
+
+	// A matrix with one dimension 0 still has rows or columns, all empty
+	if n := matrix.New[int](3, 0).Row(2).Len(); n != 0 {
+		t.Errorf("Row(2) of 3x0: Len() = %d, want 0", n)
+	}
+	if n := matrix.New[int](0, 3).Col(2).Len(); n != 0 {
+		t.Errorf("Col(2) of 0x3: Len() = %d, want 0", n)
+	}
+	for _, f := range []func(){
+		func() { m.Row(2) },
+		func() { m.Row(-1) },
+		func() { m.Col(3) },
+		func() { matrix.New[int](3, 0).Row(3) },
+		func() { matrix.New[int](0, 3).Col(3) },
+	} {
+		if !panics(f) {
+			t.Error("out of range view did not panic")
+		}
+	}
+}
+
+// Report whether f panics.
+func panics(f func()) (panicked bool) {
+	defer func() { panicked = recover() != nil }()
+	f()
+	return false
+}
+
+func TestDet(t *testing.T) {
+	tests := []struct {
+		rows [][]float64
+		want float64
+	}{
+		{[][]float64{{4, 7}, {2, 6}}, 10},
+		{[][]float64{{0, 1}, {1, 0}}, -1},
+		{[][]float64{{2, 0, 0}, {0, 3, 0}, {0, 0, 4}}, 24},
+		{[][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 10}}, -3},
+		{[][]float64{{1, 2}, {2, 4}}, 0},
+		{[][]float64{}, 1},
+	}
+	for _, tt := range tests {
+		got, err := matrix.Det(fromRows(t, tt.rows))
+		if err != nil || math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Det(%v) = %v, %v, want %v", tt.rows, got, err, tt.want)
+		}
+	}
+	if _, err := matrix.Det(matrix.New[float64](2, 3)); !errors.Is(err, matrix.ErrNotSquare) {
+		t.Errorf("Det of 2x3: err = %v, want ErrNotSquare", err)
+	}
+}
+
+func TestInverse(t *testing.T) {
+	for _, rows := range [][][]float64{
+		{{4, 7}, {2, 6}},
+		{{0, 1}, {1, 0}},
+		{{1, 2, 3}, {4, 5, 6}, {7, 8, 10}},
+		{{2, -1, 0}, {-1, 2, -1}, {0, -1, 2}},
+	} {
+		m := fromRows(t, rows)
+		inv, err := matrix.Inverse(m)
+		if err != nil {
+			t.Fatalf("Inverse(%v): %v", rows, err)
+		}
+		prod, _ := matrix.Mul(m, inv)
+		id := matrix.Identity[float64](m.Rows())
+		for k, v := range prod.Data() {
+			if math.Abs(v-id.Data()[k]) > 1e-9 {
+				t.Errorf("m × Inverse(m) for %v =\n%v", rows, prod)
+				break
+			}
+		}
+	}
+
+	want := fromRows(t, [][]float64{{0.6, -0.7}, {-0.2, 0.4}})
+	inv, _ := matrix.Inverse(fromRows(t, [][]float64{{4, 7}, {2, 6}}))
+	for k, v := range inv.Data() {
+		if math.Abs(v-want.Data()[k]) > 1e-12 {
+			t.Errorf("Inverse =\n%v, want\n%v", inv, want)
+			break
+		}
+	}
+	if _, err := matrix.Inverse(fromRows(t, [][]float64{{1, 2}, {2, 4}})); !errors.Is(err, matrix.ErrSingular) {
+		t.Errorf("Inverse of a singular matrix: err = %v, want ErrSingular", err)
+	}
+	if _, err := matrix.Inverse(matrix.New[float64](3, 2)); !errors.Is(err, matrix.ErrNotSquare) {
+		t.Errorf("Inverse of 3x2: err = %v, want ErrNotSquare", err)
+	}
+}
+
+// Return an n x n slice of slices of random values.
+func randomRows(n int) [][]float64 {
+	rows := make([][]float64, n)
+	for i := range rows {
+		rows[i] = make([]float64, n)
+		for j := range rows[i] {
+			rows[i][j] = rand.Float64()
+		}
+	}
+	return rows
+}
+
+// Multiply two matrices stored as slices of rows, with the textbook algorithm.
+// This is the baseline: every row is a separate allocation.
+func mulSlices(a, b [][]float64) [][]float64 {
+	c := make([][]float64, len(a))
+	for i := range a {
+		c[i] = make([]float64, len(b[0]))
+		for j := range b[0] {
+			var sum float64
+			for k := range b {
+				sum += a[i][k] * b[k][j]
+			}
+			c[i][j] = sum
+		}
+	}
+	return c
+}
+
+// Compare the multiplication of square float64 matrices stored as [][]float64
+// and as a Matrix, naive and cache-blocked:
+//
+//	go test -bench Mul ./src/matrix
+func BenchmarkMul(b *testing.B) {
+	for _, n := range []int{64, 128, 256, 512} {
+		rows1, rows2 := randomRows(n), randomRows(n)
+		m1 := fromRows(b, rows1)
+		m2 := fromRows(b, rows2)
+
+		b.Run(fmt.Sprintf("n=%d/slices-naive", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				mulSlices(rows1, rows2)
+			}
+		})
+		b.Run(fmt.Sprintf("n=%d/matrix-naive", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				matrix.MulNaive(m1, m2)
+			}
+		})
+		for _, block := range []int{32, matrix.DefaultBlock} {
+			b.Run(fmt.Sprintf("n=%d/matrix-blocked-%d", n, block), func(b *testing.B) {
+				b.ReportAllocs()
+				for b.Loop() {
+					matrix.MulBlocked(m1, m2, block)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,242 @@
+// Package set provides a generic set, in place of map[T]bool or map[T]string.
+//
+// A Set is backed by map[T]struct{}: the empty struct takes no memory,
+// and membership is a single comma-ok lookup.
+// Sets print and encode to JSON with their elements sorted, so the output is stable.
+package set
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// A set of unique elements. The zero value is an empty set ready to use.
+// A Set is not safe for concurrent use: see Sync.
+type Set[T comparable] struct {
+	m map[T]struct{}
+}
+
+// Create a set with the given elements. Duplicates are kept once.
+func New[T comparable](elems ...T) *Set[T] {
+	s := &Set[T]{m: make(map[T]struct{}, len(elems))}
+	s.Add(elems...)
+	return s
+}
+
+// Create a set with the elements of a sequence.
+func Collect[T comparable](seq iter.Seq[T]) *Set[T] {
+	s := New[T]()
+	for e := range seq {
+		s.m[e] = struct{}{}
+	}
+	return s
+}
+
+// Return the number of elements.
+func (s *Set[T]) Len() int {
+	return len(s.m)
+}
+
+// Add elements. Elements already in the set are ignored.
+func (s *Set[T]) Add(elems ...T) {
+	if s.m == nil {
+		s.m = make(map[T]struct{}, len(elems))
+	}
+	for _, e := range elems {
+		s.m[e] = struct{}{}
+	}
+}
+
+// Report whether an element is in the set.
+func (s *Set[T]) Has(e T) bool {
+	_, ok := s.m[e]
+	return ok
+}
+
+// Remove elements. Elements not in the set are ignored.
+func (s *Set[T]) Remove(elems ...T) {
+	for _, e := range elems {
+		delete(s.m, e)
+	}
+}
+
+// Remove all the elements.
+func (s *Set[T]) Clear() {
+	clear(s.m)
+}
+
+// Return a copy of the set.
+func (s *Set[T]) Clone() *Set[T] {
+	c := &Set[T]{m: maps.Clone(s.m)}
+	if c.m == nil {
+		c.m = map[T]struct{}{}
+	}
+	return c
+}
+
+// Iterate over the elements, in no particular order.
+func (s *Set[T]) All() iter.Seq[T] {
+	return maps.Keys(s.m)
+}
+
+// Return the elements in a slice, in no particular order.
+func (s *Set[T]) Slice() []T {
+	return slices.Collect(maps.Keys(s.m))
+}
+
+// Return the elements of s and o: s ∪ o
+func (s *Set[T]) Union(o *Set[T]) *Set[T] {
+	u := s.Clone()
+	for e := range o.m {
+		u.m[e] = struct{}{}
+	}
+	return u
+}
+
+// Return the elements of both s and o: s ∩ o
+func (s *Set[T]) Intersect(o *Set[T]) *Set[T] {
+	// Loop over the smaller set
+	small, large := s, o
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+	i := New[T]()
+	for e := range small.m {
+		if large.Has(e) {
+			i.m[e] = struct{}{}
+		}
+	}
+	return i
+}
+
+// Return the elements of s that are not in o: s \ o
+func (s *Set[T]) Difference(o *Set[T]) *Set[T] {
+	d := New[T]()
+	for e := range s.m {
+		if !o.Has(e) {
+			d.m[e] = struct{}{}
+		}
+	}
+	return d
+}
+
+// Return the elements of either s or o, but not both: s △ o
+func (s *Set[T]) SymmetricDifference(o *Set[T]) *Set[T] {
+	d := s.Difference(o)
+	for e := range o.m {
+		if !s.Has(e) {
+			d.m[e] = struct{}{}
+		}
+	}
+	return d
+}
+
+// Report whether every element of s is in o: s ⊆ o
+func (s *Set[T]) IsSubset(o *Set[T]) bool {
+	if s.Len() > o.Len() {
+		return false
+	}
+	for e := range s.m {
+		if !o.Has(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// Report whether every element of o is in s: s ⊇ o
+func (s *Set[T]) IsSuperset(o *Set[T]) bool {
+	return o.IsSubset(s)
+}
+
+// Report whether s and o have the same elements.
+func (s *Set[T]) Equal(o *Set[T]) bool {
+	return s.Len() == o.Len() && s.IsSubset(o)
+}
+
+// Return the elements of an ordered set in increasing order.
+func Sorted[T cmp.Ordered](s *Set[T]) []T {
+	return slices.Sorted(maps.Keys(s.m))
+}
+
+// Return the elements in the order of compareAny: nil first, then grouped by type name,
+// then numbers, strings and booleans by value, and other types by their printed form.
+func (s *Set[T]) sorted() []T {
+	elems := s.Slice()
+	slices.SortFunc(elems, compareAny)
+	return elems
+}
+
+// Print the set like a slice, with the elements sorted: set[1 2 3]
+// A value receiver, so that a set held by value prints too.
+func (s Set[T]) String() string {
+	var sb strings.Builder
+	sb.WriteString("set[")
+	for i, e := range s.sorted() {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		fmt.Fprint(&sb, e)
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// Encode the set as a JSON array, with the elements sorted.
+// A value receiver, so that a set held by value encodes too.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.sorted())
+}
+
+// Decode a JSON array into the set. Existing elements are kept.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var elems []T
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return err
+	}
+	s.Add(elems...)
+	return nil
+}
+
+// Compare two values: nil first, then values of different dynamic types by type name,
+// then by value for numbers, strings, and booleans, and by their printed form otherwise.
+// Sets of interface types can mix dynamic types: set.New[any](1, "a", 2.5).
+func compareAny[T any](a, b T) int {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	switch {
+	case !va.IsValid() || !vb.IsValid():
+		// A nil interface has no type: false before true puts it first
+		return cmp.Compare(boolInt(va.IsValid()), boolInt(vb.IsValid()))
+	case va.Type() != vb.Type():
+		return cmp.Compare(va.Type().String(), vb.Type().String())
+	}
+	switch va.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(va.Int(), vb.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(va.Uint(), vb.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(va.Float(), vb.Float())
+	case reflect.String:
+		return cmp.Compare(va.String(), vb.String())
+	case reflect.Bool:
+		// false before true
+		return cmp.Compare(boolInt(va.Bool()), boolInt(vb.Bool()))
+	default:
+		return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+}
+
+// Convert a boolean to 0 or 1.
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,209 @@
+package set_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/maevadevs/Go-Developer-Advanced/Composite-Types/src/set"
+)
+
+func TestStringMixedTypes(t *testing.T) {
+	tests := []struct {
+		s    *set.Set[any]
+		want string
+	}{
+		{set.New[any](1, "a", 2.5), "set[2.5 1 a]"},
+		{set.New[any](nil, 3, 1, "b", nil, true), "set[<nil> true 1 3 b]"},
+		{set.New[any](int8(2), 2, uint(2)), "set[2 2 2]"},
+	}
+	for _, tt := range tests {
+		if got := tt.s.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+
+	data, err := json.Marshal(set.New[any](1, "a", 2.5, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `[null,2.5,1,"a"]`; got != want {
+		t.Errorf("json.Marshal = %s, want %s", got, want)
+	}
+}
+
+func TestStringSorted(t *testing.T) {
+	if got, want := set.New(3, 1, 2).String(), "set[1 2 3]"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := set.New("b", "a").String(), "set[a b]"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestHeldByValue(t *testing.T) {
+	type doc struct {
+		Tags set.Set[string] `json:"tags"`
+	}
+	d := doc{Tags: *set.New("b", "a")}
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `{"tags":["a","b"]}`; got != want {
+		t.Errorf("json.Marshal of a struct field = %s, want %s", got, want)
+	}
+	byName := map[string]set.Set[int]{"x": *set.New(2, 1)}
+	if data, err = json.Marshal(byName); err != nil || string(data) != `{"x":[1,2]}` {
+		t.Errorf("json.Marshal of a map value = %s, %v", data, err)
+	}
+	if got, want := fmt.Sprint(byName["x"]), "set[1 2]"; got != want {
+		t.Errorf("fmt.Sprint of a set value = %q, want %q", got, want)
+	}
+
+	var back doc
+	if err := json.Unmarshal([]byte(`{"tags":["c","a"]}`), &back); err != nil || back.Tags.String() != "set[a c]" {
+		t.Errorf("json.Unmarshal into a struct field = %v, %v", back.Tags, err)
+	}
+}
+
+func TestOperations(t *testing.T) {
+	a, b := set.New(1, 2, 3, 4), set.New(3, 4, 5)
+	tests := []struct {
+		name string
+		got  *set.Set[int]
+		want []int
+	}{
+		{"Union", a.Union(b), []int{1, 2, 3, 4, 5}},
+		{"Intersect", a.Intersect(b), []int{3, 4}},
+		{"Intersect, larger argument", b.Intersect(a), []int{3, 4}},
+		{"Difference", a.Difference(b), []int{1, 2}},
+		{"Difference, reversed", b.Difference(a), []int{5}},
+		{"SymmetricDifference", a.SymmetricDifference(b), []int{1, 2, 5}},
+		{"with the empty set", a.Intersect(set.New[int]()), nil},
+		{"with the zero value", new(set.Set[int]).Union(b), []int{3, 4, 5}},
+	}
+	for _, tt := range tests {
+		if got := set.Sorted(tt.got); !slices.Equal(got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	// The operands are not modified
+	if got := set.Sorted(a); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("a = %v after the operations", got)
+	}
+}
+
+func TestSubset(t *testing.T) {
+	a, b, empty := set.New(1, 2), set.New(1, 2, 3), set.New[int]()
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{"a ⊆ b", a.IsSubset(b), true},
+		{"b ⊆ a", b.IsSubset(a), false},
+		{"a ⊆ a", a.IsSubset(a), true},
+		{"∅ ⊆ a", empty.IsSubset(a), true},
+		{"a ⊆ ∅", a.IsSubset(empty), false},
+		{"same length, other elements", a.IsSubset(set.New(1, 3)), false},
+		{"b ⊇ a", b.IsSuperset(a), true},
+		{"a ⊇ b", a.IsSuperset(b), false},
+		{"a = clone", a.Equal(a.Clone()), true},
+		{"a = b", a.Equal(b), false},
+		{"zero value = ∅", new(set.Set[int]).Equal(empty), true},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestBasics(t *testing.T) {
+	var s set.Set[string]
+	s.Add("a", "b", "a")
+	if s.Len() != 2 || !s.Has("a") || s.Has("c") {
+		t.Errorf("after Add: %v", &s)
+	}
+	c := s.Clone()
+	s.Remove("a", "z")
+	if s.Len() != 1 || s.Has("a") || !c.Has("a") {
+		t.Errorf("after Remove: %v, clone %v", &s, c)
+	}
+	s.Clear()
+	if s.Len() != 0 {
+		t.Errorf("after Clear: %v", &s)
+	}
+	if got := set.Sorted(set.Collect(slices.Values([]int{3, 1, 3}))); !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("Collect = %v", got)
+	}
+
+	var decoded set.Set[int]
+	decoded.Add(9)
+	if err := json.Unmarshal([]byte(`[2, 1, 2]`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if got := set.Sorted(&decoded); !slices.Equal(got, []int{1, 2, 9}) {
+		t.Errorf("Unmarshal into set[9] = %v", got)
+	}
+}
+
+func TestSync(t *testing.T) {
+	// Run with -race: every method is called from several goroutines at once
+	s := set.NewSync(0)
+	const goroutines, n = 8, 200
+	added := make([]int, goroutines)
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Go(func() {
+			for i := range n {
+				s.Add(i)
+				if s.TryAdd(n + i) {
+					added[g]++
+				}
+				s.Has(i)
+				s.Len()
+				for range s.All() {
+					// The snapshot can be modified while iterating
+					s.Remove(-1)
+					break
+				}
+				_ = s.String()
+			}
+		})
+	}
+	wg.Wait()
+
+	// Each value is added by exactly one TryAdd
+	total := 0
+	for _, a := range added {
+		total += a
+	}
+	if total != n || s.Len() != 2*n {
+		t.Errorf("TryAdd added %d values, Len = %d, want %d and %d", total, s.Len(), n, 2*n)
+	}
+	want := set.New[int]()
+	for i := range 2 * n {
+		want.Add(i)
+	}
+	if !s.Snapshot().Equal(want) {
+		t.Errorf("Sync = %v", s)
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded set.Sync[int]
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Len() != 2*n {
+		t.Errorf("round trip: %d elements, %v", decoded.Len(), err)
+	}
+	s.Remove(0)
+	s.Clear()
+	if s.Len() != 0 || s.Has(1) {
+		t.Errorf("after Clear: %v", s)
+	}
+}
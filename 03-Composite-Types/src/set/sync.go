@@ -0,0 +1,104 @@
+package set
+
+import (
+	"iter"
+	"sync"
+)
+
+// A set that is safe for concurrent use by multiple goroutines.
+// The zero value is an empty set ready to use. A Sync must not be copied after first use.
+type Sync[T comparable] struct {
+	mu sync.RWMutex
+	s  Set[T]
+}
+
+// Create a concurrent set with the given elements.
+func NewSync[T comparable](elems ...T) *Sync[T] {
+	s := &Sync[T]{}
+	s.s.Add(elems...)
+	return s
+}
+
+// Return the number of elements.
+func (s *Sync[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Len()
+}
+
+// Add elements.
+func (s *Sync[T]) Add(elems ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Add(elems...)
+}
+
+// Add an element if it is not in the set yet. Reports whether it was added.
+// Unlike Has followed by Add, no other goroutine can add it in between.
+func (s *Sync[T]) TryAdd(e T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.s.Has(e) {
+		return false
+	}
+	s.s.Add(e)
+	return true
+}
+
+// Report whether an element is in the set.
+func (s *Sync[T]) Has(e T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Has(e)
+}
+
+// Remove elements.
+func (s *Sync[T]) Remove(elems ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Remove(elems...)
+}
+
+// Remove all the elements.
+func (s *Sync[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Clear()
+}
+
+// Return a copy of the current elements, as a plain Set.
+// Set operations such as Union run on snapshots.
+func (s *Sync[T]) Snapshot() *Set[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Clone()
+}
+
+// Iterate over a snapshot of the elements, so that the loop body can modify the set.
+func (s *Sync[T]) All() iter.Seq[T] {
+	return s.Snapshot().All()
+}
+
+// Print the set with the elements sorted: set[1 2 3]
+func (s *Sync[T]) String() string {
+	return s.Snapshot().String()
+}
+
+// Encode the set as a JSON array, with the elements sorted.
+func (s *Sync[T]) MarshalJSON() ([]byte, error) {
+	return s.Snapshot().MarshalJSON()
+}
+
+// Decode a JSON array into the set. Existing elements are kept.
+func (s *Sync[T]) UnmarshalJSON(data []byte) error {
+	var elems Set[T]
+	if err := elems.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for e := range elems.All() {
+		s.s.Add(e)
+	}
+	return nil
+}
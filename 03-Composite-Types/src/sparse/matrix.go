@@ -0,0 +1,306 @@
+package sparse
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/maevadevs/Go-Developer-Advanced/Composite-Types/src/matrix"
+)
+
+// A stored element of a sparse matrix.
+type Entry[T matrix.Number] struct {
+	Row, Col int
+	Val      T
+}
+
+// A sparse matrix in coordinate format: a list of (row, column, value) entries, in any order.
+// Appending is cheap, which makes COO the format to build a matrix; convert it to CSR to compute.
+// An index can appear several times: its values are added together by ToCSR and Dense.
+type COO[T matrix.Number] struct {
+	rows, cols int
+	entries    []Entry[T]
+}
+
+// Create an empty rows x cols COO matrix.
+func NewCOO[T matrix.Number](rows, cols int) *COO[T] {
+	return &COO[T]{rows: rows, cols: cols}
+}
+
+// Create a COO matrix from the non-zero elements of a dense matrix.
+func COOFromDense[T matrix.Number](m *matrix.Matrix[T]) *COO[T] {
+	c := NewCOO[T](m.Rows(), m.Cols())
+	for k, v := range m.Data() {
+		if v != 0 {
+			c.entries = append(c.entries, Entry[T]{Row: k / m.Cols(), Col: k % m.Cols(), Val: v})
+		}
+	}
+	return c
+}
+
+// Return the number of rows.
+func (c *COO[T]) Rows() int {
+	return c.rows
+}
+
+// Return the number of columns.
+func (c *COO[T]) Cols() int {
+	return c.cols
+}
+
+// Return the number of stored entries, duplicates included.
+func (c *COO[T]) NNZ() int {
+	return len(c.entries)
+}
+
+// Add an entry. Zeros are not stored.
+func (c *COO[T]) Append(i, j int, v T) error {
+	if i < 0 || i >= c.rows || j < 0 || j >= c.cols {
+		return fmt.Errorf("%w: (%d, %d) for %dx%d", ErrIndex, i, j, c.rows, c.cols)
+	}
+	if v != 0 {
+		c.entries = append(c.entries, Entry[T]{Row: i, Col: j, Val: v})
+	}
+	return nil
+}
+
+// Iterate over the entries, in the order they were appended.
+func (c *COO[T]) All() iter.Seq[Entry[T]] {
+	return slices.Values(c.entries)
+}
+
+// Convert to a dense matrix.
+func (c *COO[T]) Dense() *matrix.Matrix[T] {
+	m := matrix.New[T](c.rows, c.cols)
+	data := m.Data()
+	for _, e := range c.entries {
+		data[e.Row*c.cols+e.Col] += e.Val
+	}
+	return m
+}
+
+// Convert to CSR: sort the entries by row then column, add the duplicates and drop the zeros.
+func (c *COO[T]) ToCSR() *CSR[T] {
+	sorted := slices.Clone(c.entries)
+	slices.SortStableFunc(sorted, func(a, b Entry[T]) int {
+		return cmp.Or(cmp.Compare(a.Row, b.Row), cmp.Compare(a.Col, b.Col))
+	})
+	out := &CSR[T]{rows: c.rows, cols: c.cols, rowPtr: make([]int, c.rows+1)}
+	for k := 0; k < len(sorted); {
+		e := sorted[k]
+		for k++; k < len(sorted) && sorted[k].Row == e.Row && sorted[k].Col == e.Col; k++ {
+			e.Val += sorted[k].Val
+		}
+		if e.Val != 0 {
+			out.colIdx = append(out.colIdx, e.Col)
+			out.vals = append(out.vals, e.Val)
+			out.rowPtr[e.Row+1]++
+		}
+	}
+	// Turn the counts per row into offsets
+	for i := range c.rows {
+		out.rowPtr[i+1] += out.rowPtr[i]
+	}
+	return out
+}
+
+// Report the memory used by the matrix.
+func (c *COO[T]) Usage() Usage {
+	return Usage{
+		Format:     "coo",
+		Len:        c.rows * c.cols,
+		NNZ:        len(c.entries),
+		Bytes:      sliceHeader + cap(c.entries)*sizeOf[Entry[T]](),
+		DenseBytes: denseBytes[T](c.rows * c.cols),
+	}
+}
+
+// A sparse matrix in compressed sparse row format.
+// The columns and the values of the stored elements are in two slices, row after row,
+// sorted by column within a row. Row i is at [rowPtr[i], rowPtr[i+1]) in both.
+type CSR[T matrix.Number] struct {
+	rows, cols int
+	rowPtr     []int
+	colIdx     []int
+	vals       []T
+}
+
+// Create a CSR matrix from the non-zero elements of a dense matrix.
+func CSRFromDense[T matrix.Number](m *matrix.Matrix[T]) *CSR[T] {
+	c := &CSR[T]{rows: m.Rows(), cols: m.Cols(), rowPtr: make([]int, m.Rows()+1)}
+	for i := range m.Rows() {
+		for j, v := range m.Row(i).All() {
+			if v != 0 {
+				c.colIdx = append(c.colIdx, j)
+				c.vals = append(c.vals, v)
+			}
+		}
+		c.rowPtr[i+1] = len(c.vals)
+	}
+	return c
+}
+
+// Return the number of rows.
+func (c *CSR[T]) Rows() int {
+	return c.rows
+}
+
+// Return the number of columns.
+func (c *CSR[T]) Cols() int {
+	return c.cols
+}
+
+// Return the number of stored elements.
+func (c *CSR[T]) NNZ() int {
+	return len(c.vals)
+}
+
+// Return element (i, j), which is zero when it is not stored.
+func (c *CSR[T]) At(i, j int) T {
+	if i < 0 || i >= c.rows {
+		panic(fmt.Sprintf("sparse: row %d out of range for %dx%d", i, c.rows, c.cols))
+	}
+	lo, hi := c.rowPtr[i], c.rowPtr[i+1]
+	if k, ok := slices.BinarySearch(c.colIdx[lo:hi], j); ok {
+		return c.vals[lo+k]
+	}
+	return 0
+}
+
+// Iterate over the stored elements of row i, by increasing column.
+func (c *CSR[T]) Row(i int) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for k := c.rowPtr[i]; k < c.rowPtr[i+1]; k++ {
+			if !yield(c.colIdx[k], c.vals[k]) {
+				return
+			}
+		}
+	}
+}
+
+// Iterate over the stored elements, row after row.
+func (c *CSR[T]) All() iter.Seq[Entry[T]] {
+	return func(yield func(Entry[T]) bool) {
+		for i := range c.rows {
+			for j, v := range c.Row(i) {
+				if !yield(Entry[T]{Row: i, Col: j, Val: v}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Convert to a dense matrix.
+func (c *CSR[T]) Dense() *matrix.Matrix[T] {
+	m := matrix.New[T](c.rows, c.cols)
+	for e := range c.All() {
+		m.Set(e.Row, e.Col, e.Val)
+	}
+	return m
+}
+
+// Convert to COO.
+func (c *CSR[T]) ToCOO() *COO[T] {
+	out := NewCOO[T](c.rows, c.cols)
+	out.entries = slices.AppendSeq(make([]Entry[T], 0, c.NNZ()), c.All())
+	return out
+}
+
+// Return the product c × b of the sparse matrix and a dense matrix.
+// Only the stored elements of c are multiplied: the cost is NNZ × b.Cols().
+func (c *CSR[T]) MulDense(b *matrix.Matrix[T]) (*matrix.Matrix[T], error) {
+	if c.cols != b.Rows() {
+		return nil, fmt.Errorf("%w: cannot multiply %dx%d by %dx%d", ErrShape, c.rows, c.cols, b.Rows(), b.Cols())
+	}
+	out := matrix.New[T](c.rows, b.Cols())
+	p, bd, od := b.Cols(), b.Data(), out.Data()
+	for i := range c.rows {
+		outRow := od[i*p : (i+1)*p]
+		for k, v := range c.Row(i) {
+			for j, bkj := range bd[k*p : (k+1)*p] {
+				outRow[j] += v * bkj
+			}
+		}
+	}
+	return out, nil
+}
+
+// Return the product c × x of the sparse matrix and a dense vector.
+func (c *CSR[T]) MulVec(x []T) ([]T, error) {
+	if c.cols != len(x) {
+		return nil, fmt.Errorf("%w: cannot multiply %dx%d by vector of length %d", ErrShape, c.rows, c.cols, len(x))
+	}
+	out := make([]T, c.rows)
+	for i := range c.rows {
+		for j, v := range c.Row(i) {
+			out[i] += v * x[j]
+		}
+	}
+	return out, nil
+}
+
+// Return c + o.
+func (c *CSR[T]) Add(o *CSR[T]) (*CSR[T], error) {
+	return c.combine(o, true, func(x, y T) T { return x + y })
+}
+
+// Return c - o.
+func (c *CSR[T]) Sub(o *CSR[T]) (*CSR[T], error) {
+	return c.combine(o, true, func(x, y T) T { return x - y })
+}
+
+// Return the element-wise product of c and o.
+func (c *CSR[T]) MulElem(o *CSR[T]) (*CSR[T], error) {
+	return c.combine(o, false, func(x, y T) T { return x * y })
+}
+
+// Combine the elements of c and o row by row, like merge does for vectors.
+func (c *CSR[T]) combine(o *CSR[T], union bool, op func(x, y T) T) (*CSR[T], error) {
+	if c.rows != o.rows || c.cols != o.cols {
+		return nil, fmt.Errorf("%w: %dx%d and %dx%d", ErrShape, c.rows, c.cols, o.rows, o.cols)
+	}
+	out := &CSR[T]{rows: c.rows, cols: c.cols, rowPtr: make([]int, c.rows+1)}
+	put := func(j int, x T) {
+		if x != 0 {
+			out.colIdx = append(out.colIdx, j)
+			out.vals = append(out.vals, x)
+		}
+	}
+	for i := range c.rows {
+		a, aEnd := c.rowPtr[i], c.rowPtr[i+1]
+		b, bEnd := o.rowPtr[i], o.rowPtr[i+1]
+		for a < aEnd || b < bEnd {
+			switch {
+			case a < aEnd && (b == bEnd || c.colIdx[a] < o.colIdx[b]):
+				if union {
+					put(c.colIdx[a], op(c.vals[a], 0))
+				}
+				a++
+			case b < bEnd && (a == aEnd || o.colIdx[b] < c.colIdx[a]):
+				if union {
+					put(o.colIdx[b], op(0, o.vals[b]))
+				}
+				b++
+			default:
+				put(c.colIdx[a], op(c.vals[a], o.vals[b]))
+				a++
+				b++
+			}
+		}
+		out.rowPtr[i+1] = len(out.vals)
+	}
+	return out, nil
+}
+
+// Report the memory used by the matrix.
+func (c *CSR[T]) Usage() Usage {
+	return Usage{
+		Format:     "csr",
+		Len:        c.rows * c.cols,
+		NNZ:        len(c.vals),
+		Bytes:      3*sliceHeader + (cap(c.rowPtr)+cap(c.colIdx))*sizeOf[int]() + cap(c.vals)*sizeOf[T](),
+		DenseBytes: denseBytes[T](c.rows * c.cols),
+	}
+}
@@ -0,0 +1,273 @@
+package sparse_test
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/maevadevs/Go-Developer-Advanced/Composite-Types/src/matrix"
+	"github.com/maevadevs/Go-Developer-Advanced/Composite-Types/src/sparse"
+)
+
+// Return a rows x cols matrix where about one element in four is non-zero, the same for the same seed.
+func random(rows, cols int, seed uint64) *matrix.Matrix[int] {
+	r := rand.New(rand.NewPCG(seed, seed))
+	m := matrix.New[int](rows, cols)
+	for k := range m.Data() {
+		if r.IntN(4) == 0 {
+			m.Data()[k] = r.IntN(19) - 9
+		}
+	}
+	return m
+}
+
+// Create a matrix from rows, or fail the test.
+func fromRows[T matrix.Number](t testing.TB, rows [][]T) *matrix.Matrix[T] {
+	t.Helper()
+	m, err := matrix.FromRows(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// Return the number of non-zero elements of s.
+func nonZero(s []int) int {
+	n := 0
+	for _, x := range s {
+		if x != 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func TestVectorDense(t *testing.T) {
+	tests := [][]int{
+		nil,
+		{0, 0, 0},
+		{1, 2, 3},
+		{0, 5, 0, 0, -2, 0, 7},
+	}
+	for _, s := range tests {
+		nnz := nonZero(s)
+		for _, v := range []sparse.Vector[int]{sparse.MapFromDense(s), sparse.IndexFromDense(s)} {
+			u := v.Usage()
+			if got := sparse.Dense(v); v.Len() != len(s) || v.NNZ() != nnz || !slices.Equal(got, s) {
+				t.Errorf("%s from %v: Dense = %v, len %d, nnz %d", u.Format, s, got, v.Len(), v.NNZ())
+			}
+			for i, x := range s {
+				if v.At(i) != x {
+					t.Errorf("%s from %v: At(%d) = %d", u.Format, s, i, v.At(i))
+				}
+			}
+			if u.Len != len(s) || u.NNZ != nnz {
+				t.Errorf("%s from %v: Usage = %v", u.Format, s, u)
+			}
+		}
+	}
+}
+
+func TestSet(t *testing.T) {
+	for _, v := range []interface {
+		sparse.Vector[int]
+		Set(i, x int) error
+	}{sparse.NewMap[int](5), sparse.NewIndex[int](5)} {
+		for _, op := range [][2]int{{3, 1}, {0, 2}, {4, 3}, {3, 4}, {0, 0}, {1, 0}} {
+			if err := v.Set(op[0], op[1]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		format := v.Usage().Format
+		if got := sparse.Dense(v); !slices.Equal(got, []int{0, 0, 0, 4, 3}) || v.NNZ() != 2 {
+			t.Errorf("%s: after the sets, Dense = %v, nnz %d", format, got, v.NNZ())
+		}
+		var idx []int
+		for i := range v.All() {
+			idx = append(idx, i)
+		}
+		if !slices.Equal(idx, []int{3, 4}) {
+			t.Errorf("%s: All indexes = %v, want increasing", format, idx)
+		}
+		for _, i := range []int{-1, 5} {
+			if err := v.Set(i, 1); !errors.Is(err, sparse.ErrIndex) {
+				t.Errorf("%s: Set(%d) = %v, want ErrIndex", format, i, err)
+			}
+		}
+	}
+}
+
+func TestVectorOps(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []int
+	}{
+		{"empty", nil, nil},
+		{"disjoint", []int{1, 0, 2, 0}, []int{0, 3, 0, 4}},
+		{"shared", []int{1, 2, 0, 3}, []int{1, -2, 5, 3}},
+		{"cancel", []int{4, 0, -1}, []int{4, 0, -1}},
+	}
+	for _, tt := range tests {
+		var add, sub, mul []int
+		dot := 0
+		for i := range tt.a {
+			add = append(add, tt.a[i]+tt.b[i])
+			sub = append(sub, tt.a[i]-tt.b[i])
+			mul = append(mul, tt.a[i]*tt.b[i])
+			dot += tt.a[i] * tt.b[i]
+		}
+		// Mix the two formats: the operations take any Vector
+		a, b := sparse.MapFromDense(tt.a), sparse.IndexFromDense(tt.b)
+		for _, op := range []struct {
+			name string
+			fn   func(a, b sparse.Vector[int]) (*sparse.IndexVector[int], error)
+			want []int
+		}{
+			{"Add", sparse.Add[int], add},
+			{"Sub", sparse.Sub[int], sub},
+			{"Mul", sparse.Mul[int], mul},
+		} {
+			got, err := op.fn(a, b)
+			if err != nil {
+				t.Fatalf("%s: %s: %v", tt.name, op.name, err)
+			}
+			// Zero results are not stored
+			if d := sparse.Dense(got); !slices.Equal(d, op.want) || got.NNZ() != nonZero(op.want) {
+				t.Errorf("%s: %s = %v with %d stored, want %v", tt.name, op.name, d, got.NNZ(), op.want)
+			}
+		}
+		if got, err := sparse.Dot[int](a, b); err != nil || got != dot {
+			t.Errorf("%s: Dot = %d, %v, want %d", tt.name, got, err, dot)
+		}
+	}
+
+	a, b := sparse.NewIndex[int](3), sparse.NewMap[int](4)
+	if _, err := sparse.Add[int](a, b); !errors.Is(err, sparse.ErrShape) {
+		t.Errorf("Add of lengths 3 and 4 = %v, want ErrShape", err)
+	}
+	if _, err := sparse.Dot[int](a, b); !errors.Is(err, sparse.ErrShape) {
+		t.Errorf("Dot of lengths 3 and 4 = %v, want ErrShape", err)
+	}
+}
+
+func TestMatrixDense(t *testing.T) {
+	shapes := [][2]int{{0, 0}, {1, 1}, {3, 4}, {10, 7}, {4, 0}}
+	for k, sh := range shapes {
+		m := random(sh[0], sh[1], uint64(k))
+		nnz := nonZero(m.Data())
+		coo := sparse.COOFromDense(m)
+		csr := sparse.CSRFromDense(m)
+		if !coo.Dense().Equal(m) || !csr.Dense().Equal(m) || !coo.ToCSR().Dense().Equal(m) || !csr.ToCOO().Dense().Equal(m) {
+			t.Errorf("%v: a conversion does not give back\n%v", sh, m)
+		}
+		if coo.NNZ() != nnz || csr.NNZ() != nnz || csr.Rows() != sh[0] || csr.Cols() != sh[1] {
+			t.Errorf("%v: nnz %d and %d, want %d", sh, coo.NNZ(), csr.NNZ(), nnz)
+		}
+		for i := range sh[0] {
+			for j := range sh[1] {
+				if csr.At(i, j) != m.At(i, j) {
+					t.Errorf("%v: At(%d, %d) = %d, want %d", sh, i, j, csr.At(i, j), m.At(i, j))
+				}
+			}
+		}
+	}
+}
+
+func TestCOODuplicates(t *testing.T) {
+	c := sparse.NewCOO[int](2, 3)
+	entries := []sparse.Entry[int]{
+		{Row: 1, Col: 2, Val: 5}, {Row: 0, Col: 1, Val: 1}, {Row: 1, Col: 2, Val: 2},
+		{Row: 0, Col: 0, Val: 4}, {Row: 0, Col: 1, Val: 3}, {Row: 0, Col: 0, Val: -4},
+		{Row: 1, Col: 0, Val: 0},
+	}
+	for _, e := range entries {
+		if err := c.Append(e.Row, e.Col, e.Val); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The zero is not stored, the duplicates are until the conversion
+	if c.NNZ() != 6 {
+		t.Errorf("COO NNZ = %d, want 6", c.NNZ())
+	}
+	want := fromRows(t, [][]int{{0, 4, 0}, {0, 0, 7}})
+	if got := c.Dense(); !got.Equal(want) {
+		t.Errorf("Dense =\n%v\nwant\n%v", got, want)
+	}
+	csr := c.ToCSR()
+	// (0, 0) adds up to zero: it is dropped
+	got := slices.Collect(csr.All())
+	if !slices.Equal(got, []sparse.Entry[int]{{Row: 0, Col: 1, Val: 4}, {Row: 1, Col: 2, Val: 7}}) {
+		t.Errorf("ToCSR entries = %v", got)
+	}
+
+	for _, ij := range [][2]int{{-1, 0}, {2, 0}, {0, 3}} {
+		if err := c.Append(ij[0], ij[1], 1); !errors.Is(err, sparse.ErrIndex) {
+			t.Errorf("Append(%d, %d) = %v, want ErrIndex", ij[0], ij[1], err)
+		}
+	}
+}
+
+func TestMatrixOps(t *testing.T) {
+	a, b := random(6, 5, 1), random(6, 5, 2)
+	sa, sb := sparse.CSRFromDense(a), sparse.CSRFromDense(b)
+	tests := []struct {
+		name string
+		fn   func(o *sparse.CSR[int]) (*sparse.CSR[int], error)
+		op   func(x, y int) int
+	}{
+		{"Add", sa.Add, func(x, y int) int { return x + y }},
+		{"Sub", sa.Sub, func(x, y int) int { return x - y }},
+		{"MulElem", sa.MulElem, func(x, y int) int { return x * y }},
+	}
+	for _, tt := range tests {
+		want := matrix.New[int](6, 5)
+		for k := range want.Data() {
+			want.Data()[k] = tt.op(a.Data()[k], b.Data()[k])
+		}
+		got, err := tt.fn(sb)
+		if err != nil || !got.Dense().Equal(want) {
+			t.Errorf("%s = %v\nwant\n%v", tt.name, err, want)
+		}
+		if got.NNZ() != nonZero(want.Data()) {
+			t.Errorf("%s stores %d elements, zeros included", tt.name, got.NNZ())
+		}
+	}
+	if got, err := sa.Sub(sa); err != nil || got.NNZ() != 0 {
+		t.Errorf("a - a stores %d elements, %v", got.NNZ(), err)
+	}
+	if _, err := sa.Add(sparse.CSRFromDense(random(5, 6, 1))); !errors.Is(err, sparse.ErrShape) {
+		t.Errorf("Add of 6x5 and 5x6 = %v, want ErrShape", err)
+	}
+}
+
+func TestMul(t *testing.T) {
+	shapes := [][3]int{{1, 1, 1}, {2, 3, 4}, {7, 5, 3}, {17, 33, 9}, {0, 3, 2}, {3, 0, 2}, {3, 2, 0}}
+	for k, sh := range shapes {
+		a, b := random(sh[0], sh[1], uint64(2*k)), random(sh[1], sh[2], uint64(2*k+1))
+		want, err := matrix.Mul(a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sa := sparse.CSRFromDense(a)
+		if got, err := sa.MulDense(b); err != nil || !got.Equal(want) {
+			t.Errorf("MulDense of %v differs from Mul: %v", sh, err)
+		}
+		// Each column of b is a vector
+		for j := range sh[2] {
+			x := b.Col(j).Slice()
+			got, err := sa.MulVec(x)
+			if err != nil || !slices.Equal(got, want.Col(j).Slice()) {
+				t.Errorf("MulVec of %v by column %d = %v, %v, want %v", sh, j, got, err, want.Col(j).Slice())
+			}
+		}
+	}
+
+	sa := sparse.CSRFromDense(random(2, 3, 1))
+	if _, err := sa.MulDense(matrix.New[int](2, 2)); !errors.Is(err, sparse.ErrShape) {
+		t.Errorf("MulDense of 2x3 by 2x2 = %v, want ErrShape", err)
+	}
+	if _, err := sa.MulVec(make([]int, 2)); !errors.Is(err, sparse.ErrShape) {
+		t.Errorf("MulVec of 2x3 by length 2 = %v, want ErrShape", err)
+	}
+}
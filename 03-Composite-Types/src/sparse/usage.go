@@ -0,0 +1,79 @@
+package sparse
+
+import (
+	"fmt"
+	"io"
+	"math/bits"
+	"text/tabwriter"
+	"unsafe"
+)
+
+// The memory used by a sparse vector or matrix, compared with its dense form.
+// Sizes are estimates: they count the slice and map headers and the backing arrays,
+// with their capacity, but not the allocator overhead.
+type Usage struct {
+	// Storage layout: map, index, coo or csr
+	Format string
+	// Number of elements, zeros included, and number of stored elements
+	Len, NNZ int
+	// Estimated size of the sparse form and of the dense form, in bytes
+	Bytes, DenseBytes int
+}
+
+// Return the fraction of the elements that are stored.
+func (u Usage) Density() float64 {
+	if u.Len == 0 {
+		return 0
+	}
+	return float64(u.NNZ) / float64(u.Len)
+}
+
+// Return Bytes / DenseBytes: below 1, the sparse form is smaller.
+func (u Usage) Ratio() float64 {
+	if u.DenseBytes == 0 {
+		return 0
+	}
+	return float64(u.Bytes) / float64(u.DenseBytes)
+}
+
+// Print the usage on one line: csr: 198 of 10000 stored (2.0%), 4976 B vs 80024 B dense (0.062x)
+func (u Usage) String() string {
+	return fmt.Sprintf("%s: %d of %d stored (%.1f%%), %d B vs %d B dense (%.3fx)",
+		u.Format, u.NNZ, u.Len, 100*u.Density(), u.Bytes, u.DenseBytes, u.Ratio())
+}
+
+// Write the usages as a table, one per line.
+func WriteUsage(w io.Writer, usages ...Usage) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "FORMAT\tLEN\tNNZ\tDENSITY\tBYTES\tDENSE BYTES\tRATIO\t")
+	for _, u := range usages {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f%%\t%d\t%d\t%.3fx\t\n",
+			u.Format, u.Len, u.NNZ, 100*u.Density(), u.Bytes, u.DenseBytes, u.Ratio())
+	}
+	return tw.Flush()
+}
+
+// Size of a slice header: pointer, length and capacity.
+var sliceHeader = int(unsafe.Sizeof([]byte(nil)))
+
+// Return the size of a value of type T in bytes.
+func sizeOf[T any]() int {
+	return int(unsafe.Sizeof(*new(T)))
+}
+
+// Return the size of n elements of type T stored densely in a slice.
+func denseBytes[T any](n int) int {
+	return sliceHeader + n*sizeOf[T]()
+}
+
+// Estimate the size of a map[K]V with n entries.
+// The runtime stores entries in groups of 8 slots, each group with 8 control bytes,
+// and grows the table when it is more than 7/8 full.
+func mapBytes[K comparable, V any](n int) int {
+	const header, slotsPerGroup = 48, 8
+	slots := slotsPerGroup
+	if need := (n*8 + 6) / 7; need > slots {
+		slots = 1 << bits.Len(uint(need-1))
+	}
+	return header + slots*(sizeOf[K]()+sizeOf[V]()) + slots
+}
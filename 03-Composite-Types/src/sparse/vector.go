@@ -0,0 +1,287 @@
+// Package sparse stores vectors and matrices by their non-zero elements only.
+//
+// A sparse literal like []int{1, 5: 4, 6} only writes the non-zero elements,
+// but the slice still stores every zero. When most elements are zero,
+// storing the pairs (index, value) takes less memory and skips the zeros in computations.
+//
+// Vectors come in two layouts: MapVector, a map from index to value, cheap to update in any order,
+// and IndexVector, sorted slices of indexes and values, compact and fast to iterate.
+// Matrices come as COO, a list of (row, column, value) triplets that is easy to build,
+// and CSR, compressed rows that are fast to multiply.
+// The dense forms are []T and *matrix.Matrix[T].
+package sparse
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+
+	"github.com/maevadevs/Go-Developer-Advanced/Composite-Types/src/matrix"
+)
+
+// Errors returned by the operations on sparse vectors and matrices.
+var (
+	ErrShape = errors.New("sparse: shapes do not match")
+	ErrIndex = errors.New("sparse: index out of range")
+)
+
+// A sparse vector of length Len. Only the non-zero elements are stored.
+type Vector[T matrix.Number] interface {
+	// Return the length, zeros included.
+	Len() int
+	// Return the number of stored elements.
+	NNZ() int
+	// Return element i, which is zero when it is not stored.
+	At(i int) T
+	// Iterate over the stored elements by increasing index.
+	All() iter.Seq2[int, T]
+	// Report the memory used by the vector.
+	Usage() Usage
+}
+
+// A sparse vector stored in a map from index to value.
+// Setting an element is O(1), iterating in order needs a sort of the indexes.
+type MapVector[T matrix.Number] struct {
+	n    int
+	vals map[int]T
+}
+
+// Create a MapVector of length n with no non-zero element.
+func NewMap[T matrix.Number](n int) *MapVector[T] {
+	return &MapVector[T]{n: n, vals: make(map[int]T)}
+}
+
+// Create a MapVector from a dense slice.
+func MapFromDense[T matrix.Number](s []T) *MapVector[T] {
+	v := NewMap[T](len(s))
+	for i, x := range s {
+		if x != 0 {
+			v.vals[i] = x
+		}
+	}
+	return v
+}
+
+// Return the length, zeros included.
+func (v *MapVector[T]) Len() int {
+	return v.n
+}
+
+// Return the number of stored elements.
+func (v *MapVector[T]) NNZ() int {
+	return len(v.vals)
+}
+
+// Return element i, which is zero when it is not stored.
+func (v *MapVector[T]) At(i int) T {
+	return v.vals[i]
+}
+
+// Set element i. Setting a zero removes the element.
+func (v *MapVector[T]) Set(i int, x T) error {
+	if i < 0 || i >= v.n {
+		return fmt.Errorf("%w: %d for length %d", ErrIndex, i, v.n)
+	}
+	if x == 0 {
+		delete(v.vals, i)
+		return nil
+	}
+	v.vals[i] = x
+	return nil
+}
+
+// Iterate over the stored elements by increasing index.
+func (v *MapVector[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for _, i := range slices.Sorted(maps.Keys(v.vals)) {
+			if !yield(i, v.vals[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Report the memory used by the vector.
+func (v *MapVector[T]) Usage() Usage {
+	return Usage{
+		Format:     "map",
+		Len:        v.n,
+		NNZ:        len(v.vals),
+		Bytes:      mapBytes[int, T](len(v.vals)),
+		DenseBytes: denseBytes[T](v.n),
+	}
+}
+
+// A sparse vector stored as two slices: the sorted indexes of the non-zero elements, and their values.
+// Reading an element is a binary search, setting a new one shifts the elements after it.
+type IndexVector[T matrix.Number] struct {
+	n    int
+	idx  []int
+	vals []T
+}
+
+// Create an IndexVector of length n with no non-zero element.
+func NewIndex[T matrix.Number](n int) *IndexVector[T] {
+	return &IndexVector[T]{n: n}
+}
+
+// Create an IndexVector from a dense slice.
+func IndexFromDense[T matrix.Number](s []T) *IndexVector[T] {
+	v := NewIndex[T](len(s))
+	for i, x := range s {
+		if x != 0 {
+			v.idx = append(v.idx, i)
+			v.vals = append(v.vals, x)
+		}
+	}
+	return v
+}
+
+// Copy any sparse vector into an IndexVector.
+func ToIndex[T matrix.Number](v Vector[T]) *IndexVector[T] {
+	out := &IndexVector[T]{n: v.Len(), idx: make([]int, 0, v.NNZ()), vals: make([]T, 0, v.NNZ())}
+	for i, x := range v.All() {
+		out.idx = append(out.idx, i)
+		out.vals = append(out.vals, x)
+	}
+	return out
+}
+
+// Return the length, zeros included.
+func (v *IndexVector[T]) Len() int {
+	return v.n
+}
+
+// Return the number of stored elements.
+func (v *IndexVector[T]) NNZ() int {
+	return len(v.idx)
+}
+
+// Return element i, which is zero when it is not stored.
+func (v *IndexVector[T]) At(i int) T {
+	if k, ok := slices.BinarySearch(v.idx, i); ok {
+		return v.vals[k]
+	}
+	return 0
+}
+
+// Set element i. Setting a zero removes the element.
+func (v *IndexVector[T]) Set(i int, x T) error {
+	if i < 0 || i >= v.n {
+		return fmt.Errorf("%w: %d for length %d", ErrIndex, i, v.n)
+	}
+	k, ok := slices.BinarySearch(v.idx, i)
+	switch {
+	case ok && x == 0:
+		v.idx = slices.Delete(v.idx, k, k+1)
+		v.vals = slices.Delete(v.vals, k, k+1)
+	case ok:
+		v.vals[k] = x
+	case x != 0:
+		v.idx = slices.Insert(v.idx, k, i)
+		v.vals = slices.Insert(v.vals, k, x)
+	}
+	return nil
+}
+
+// Iterate over the stored elements by increasing index.
+func (v *IndexVector[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for k, i := range v.idx {
+			if !yield(i, v.vals[k]) {
+				return
+			}
+		}
+	}
+}
+
+// Report the memory used by the vector.
+func (v *IndexVector[T]) Usage() Usage {
+	return Usage{
+		Format:     "index",
+		Len:        v.n,
+		NNZ:        len(v.idx),
+		Bytes:      2*sliceHeader + cap(v.idx)*sizeOf[int]() + cap(v.vals)*sizeOf[T](),
+		DenseBytes: denseBytes[T](v.n),
+	}
+}
+
+// Return the vector as a dense slice.
+func Dense[T matrix.Number](v Vector[T]) []T {
+	s := make([]T, v.Len())
+	for i, x := range v.All() {
+		s[i] = x
+	}
+	return s
+}
+
+// Return a + b.
+func Add[T matrix.Number](a, b Vector[T]) (*IndexVector[T], error) {
+	return merge(a, b, true, func(x, y T) T { return x + y })
+}
+
+// Return a - b.
+func Sub[T matrix.Number](a, b Vector[T]) (*IndexVector[T], error) {
+	return merge(a, b, true, func(x, y T) T { return x - y })
+}
+
+// Return the element-wise product of a and b. Only the indexes stored in both can be non-zero.
+func Mul[T matrix.Number](a, b Vector[T]) (*IndexVector[T], error) {
+	return merge(a, b, false, func(x, y T) T { return x * y })
+}
+
+// Return the dot product of a and b.
+func Dot[T matrix.Number](a, b Vector[T]) (T, error) {
+	p, err := Mul(a, b)
+	if err != nil {
+		return 0, err
+	}
+	var sum T
+	for _, x := range p.vals {
+		sum += x
+	}
+	return sum, nil
+}
+
+// Combine the elements of a and b with op, walking both in index order.
+// With union, an index stored in only one vector is combined with a zero; without, it is skipped.
+// Results that are zero are not stored.
+func merge[T matrix.Number](a, b Vector[T], union bool, op func(x, y T) T) (*IndexVector[T], error) {
+	if a.Len() != b.Len() {
+		return nil, fmt.Errorf("%w: vectors of length %d and %d", ErrShape, a.Len(), b.Len())
+	}
+	out := NewIndex[T](a.Len())
+	put := func(i int, x T) {
+		if x != 0 {
+			out.idx = append(out.idx, i)
+			out.vals = append(out.vals, x)
+		}
+	}
+	nextA, stopA := iter.Pull2(a.All())
+	defer stopA()
+	nextB, stopB := iter.Pull2(b.All())
+	defer stopB()
+	i, x, okA := nextA()
+	j, y, okB := nextB()
+	for okA || okB {
+		switch {
+		case okA && (!okB || i < j):
+			if union {
+				put(i, op(x, 0))
+			}
+			i, x, okA = nextA()
+		case okB && (!okA || j < i):
+			if union {
+				put(j, op(0, y))
+			}
+			j, y, okB = nextB()
+		default:
+			put(i, op(x, y))
+			i, x, okA = nextA()
+			j, y, okB = nextB()
+		}
+	}
+	return out, nil
+}
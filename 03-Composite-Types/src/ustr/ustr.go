@@ -0,0 +1,165 @@
+// Package ustr works on strings by runes, grapheme clusters and display columns instead of bytes.
+//
+// Indexing and slicing a string count bytes: strB[4:7] can cut 😊 in the middle,
+// and len counts 4 bytes for it. A rune is one code point, but what a reader sees as one character,
+// a grapheme cluster, can be several runes: a flag, an emoji with a skin tone, a letter with an accent.
+// On a terminal, East Asian wide characters and most emoji also take two columns instead of one.
+//
+// Grapheme clusters and widths follow the Unicode rules as implemented by github.com/rivo/uniseg.
+// Invalid UTF-8 never panics: each invalid byte counts as one rune, one cluster of width 1.
+package ustr
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// Return the runes [start:end) of s. Indexes count runes, not bytes.
+// Out-of-range indexes are clamped to the string, and an empty range gives "".
+func Substr(s string, start, end int) string {
+	start, end = max(start, 0), max(end, 0)
+	lo, hi := len(s), len(s)
+	i := 0
+	for offset := range s {
+		if i == start {
+			lo = offset
+		}
+		if i == end {
+			hi = offset
+			break
+		}
+		i++
+	}
+	if lo >= hi {
+		return ""
+	}
+	return s[lo:hi]
+}
+
+// Iterate over the grapheme clusters of s, with their display width.
+func clusters(s string, yield func(cluster string, width int) bool) {
+	state := -1
+	for s != "" {
+		var cluster string
+		var width int
+		cluster, s, width, state = uniseg.FirstGraphemeClusterInString(s, state)
+		if !yield(cluster, width) {
+			return
+		}
+	}
+}
+
+// Return s with its grapheme clusters in reverse order.
+// Reversing runes would split the clusters: 🇫🇷 would become 🇷🇫, and an accent would move
+// to the previous letter.
+func Reverse(s string) string {
+	var parts []string
+	clusters(s, func(c string, _ int) bool {
+		parts = append(parts, c)
+		return true
+	})
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for i := len(parts) - 1; i >= 0; i-- {
+		sb.WriteString(parts[i])
+	}
+	return sb.String()
+}
+
+// Return the number of columns s takes on a terminal with a monospace font.
+// East Asian wide characters and emoji take 2 columns, combining marks and zero-width characters 0.
+func Width(s string) int {
+	return uniseg.StringWidth(s)
+}
+
+// Shorten s to at most width columns, ending with the ellipsis when something was cut.
+// The cut falls between grapheme clusters. When even the ellipsis does not fit, s is cut without it.
+//
+//	Truncate("Hello 😊!", 7, "…") == "Hello …"
+func Truncate(s string, width int, ellipsis string) string {
+	if Width(s) <= width {
+		return s
+	}
+	room := width - Width(ellipsis)
+	if room < 0 {
+		room, ellipsis = width, ""
+	}
+	end, used := 0, 0
+	clusters(s, func(c string, w int) bool {
+		if used+w > room {
+			return false
+		}
+		used += w
+		end += len(c)
+		return true
+	})
+	return s[:end] + ellipsis
+}
+
+// Pad s on the left with pad to width columns. A string already as wide is returned unchanged.
+// When a wide pad character cannot fill the last column, a space does.
+func PadLeft(s string, width int, pad rune) string {
+	return padding(Width(s), width, pad) + s
+}
+
+// Pad s on the right with pad to width columns, like PadLeft.
+func PadRight(s string, width int, pad rune) string {
+	return s + padding(Width(s), width, pad)
+}
+
+// Return the padding from have columns to want columns.
+func padding(have, want int, pad rune) string {
+	missing := want - have
+	if missing <= 0 {
+		return ""
+	}
+	padWidth := uniseg.StringWidth(string(pad))
+	if padWidth < 1 {
+		pad, padWidth = ' ', 1
+	}
+	return strings.Repeat(string(pad), missing/padWidth) + strings.Repeat(" ", missing%padWidth)
+}
+
+// A run of invalid UTF-8 bytes.
+type Span struct {
+	Offset int
+	Bytes  []byte
+}
+
+// The error returned by ValidateUTF8: the invalid bytes of a string, by increasing offset.
+type InvalidUTF8Error struct {
+	Spans []Span
+}
+
+// List the invalid bytes with their offsets.
+func (e *InvalidUTF8Error) Error() string {
+	parts := make([]string, len(e.Spans))
+	for i, sp := range e.Spans {
+		parts[i] = fmt.Sprintf("% x at byte %d", sp.Bytes, sp.Offset)
+	}
+	return "invalid UTF-8: " + strings.Join(parts, ", ")
+}
+
+// Check that s is valid UTF-8. If not, return an *InvalidUTF8Error that reports
+// every run of consecutive invalid bytes with its offset.
+func ValidateUTF8(s string) error {
+	if utf8.ValidString(s) {
+		return nil
+	}
+	var spans []Span
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			if n := len(spans); n > 0 && spans[n-1].Offset+len(spans[n-1].Bytes) == i {
+				spans[n-1].Bytes = append(spans[n-1].Bytes, s[i])
+			} else {
+				spans = append(spans, Span{Offset: i, Bytes: []byte{s[i]}})
+			}
+		}
+		i += size
+	}
+	return &InvalidUTF8Error{Spans: spans}
+}
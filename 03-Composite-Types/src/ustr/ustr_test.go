@@ -0,0 +1,166 @@
+package ustr_test
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/maevadevs/Go-Developer-Advanced/Composite-Types/src/ustr"
+)
+
+// The pieces of the seed corpus: ASCII, accented letters, wide characters, emoji sequences,
+// flags, combining marks, and invalid bytes.
+var pieces = []string{
+	"a", "Z", " ", "!", "é", "é", "ß", "日", "本", "ｱ",
+	"😊", "👍🏽", "👩‍👩‍👧", "🇫🇷", "🇯", "‍", "́", "\t", "\x00",
+	// Invalid: lone continuation bytes, truncated sequences, surrogates, overlong encodings
+	"\x80", "\xbf", "\xff", "\xc3", "\xe2\x82", "\xf0\x9f\x98", "\xed\xa0\x80", "\xc0\xaf",
+}
+
+// Return each piece alone, then every piece next to every invalid one and to a letter.
+func seeds() []string {
+	seeds := slices.Clone(pieces)
+	for _, p := range pieces {
+		for _, q := range []string{"a", "́", "\x80", "\xf0\x9f\x98", "🇫"} {
+			seeds = append(seeds, p+q, q+p)
+		}
+	}
+	return append(seeds, "", strings.Join(pieces, ""), "Hello, 世界! 👋🏽 🇫🇷")
+}
+
+func FuzzSubstr(f *testing.F) {
+	for i, s := range seeds() {
+		f.Add(s, i%6)
+	}
+	f.Fuzz(func(t *testing.T, s string, i int) {
+		// Splitting at any rune index and joining gives s back
+		head, tail := ustr.Substr(s, 0, i), ustr.Substr(s, i, len(s)+1)
+		if head+tail != s {
+			t.Errorf("Substr(s, 0, %d) + Substr(s, %d, ∞) = %q + %q, want %q", i, i, head, tail, s)
+		}
+		if n := utf8.RuneCountInString(head); i >= 0 && n != min(i, utf8.RuneCountInString(s)) {
+			t.Errorf("Substr(s, 0, %d) has %d runes", i, n)
+		}
+	})
+}
+
+func FuzzReverse(f *testing.F) {
+	for _, s := range seeds() {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		// The same bytes in another order
+		r := ustr.Reverse(s)
+		x, y := []byte(r), []byte(s)
+		slices.Sort(x)
+		slices.Sort(y)
+		if !bytes.Equal(x, y) {
+			t.Errorf("Reverse(%q) = %q does not have the bytes of s", s, r)
+		}
+	})
+}
+
+func FuzzTruncate(f *testing.F) {
+	for i, s := range seeds() {
+		f.Add(s, uint8(i%12))
+	}
+	f.Fuzz(func(t *testing.T, s string, w uint8) {
+		// A prefix of s, then the ellipsis, within the width
+		width := int(w)
+		got := ustr.Truncate(s, width, "…")
+		if got == s {
+			if ustr.Width(s) > width {
+				t.Errorf("Truncate(%q, %d) returned s, %d columns wide", s, width, ustr.Width(s))
+			}
+			return
+		}
+		if ustr.Width(got) > width {
+			t.Errorf("Truncate(%q, %d) = %q is %d columns wide", s, width, got, ustr.Width(got))
+		}
+		if !strings.HasPrefix(s, strings.TrimSuffix(got, "…")) {
+			t.Errorf("Truncate(%q, %d) = %q is not a prefix of s", s, width, got)
+		}
+	})
+}
+
+func FuzzPad(f *testing.F) {
+	for i, s := range seeds() {
+		f.Add(s, uint8(i%12))
+	}
+	f.Fuzz(func(t *testing.T, s string, w uint8) {
+		// s and exactly the missing columns, with a narrow and a wide pad
+		width := int(w)
+		want := max(width-ustr.Width(s), 0)
+		if p := ustr.PadLeft(s, width, '.'); !strings.HasSuffix(p, s) || ustr.Width(p[:len(p)-len(s)]) != want {
+			t.Errorf("PadLeft(%q, %d) = %q", s, width, p)
+		}
+		if p := ustr.PadRight(s, width, '日'); !strings.HasPrefix(p, s) || ustr.Width(p[len(s):]) != want {
+			t.Errorf("PadRight(%q, %d) = %q", s, width, p)
+		}
+	})
+}
+
+func FuzzValidateUTF8(f *testing.F) {
+	for _, s := range seeds() {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		// An error exactly for invalid strings, and removing the spans leaves valid UTF-8
+		err := ustr.ValidateUTF8(s)
+		var inv *ustr.InvalidUTF8Error
+		switch {
+		case utf8.ValidString(s):
+			if err != nil {
+				t.Fatalf("ValidateUTF8(%q) of valid UTF-8: %v", s, err)
+			}
+			return
+		case !errors.As(err, &inv):
+			t.Fatalf("ValidateUTF8(%q) of invalid UTF-8: %v", s, err)
+		}
+		var rest []byte
+		prev := 0
+		for _, sp := range inv.Spans {
+			if sp.Offset < prev || sp.Offset+len(sp.Bytes) > len(s) || s[sp.Offset:sp.Offset+len(sp.Bytes)] != string(sp.Bytes) {
+				t.Fatalf("ValidateUTF8(%q): wrong span % x at byte %d", s, sp.Bytes, sp.Offset)
+			}
+			rest = append(rest, s[prev:sp.Offset]...)
+			prev = sp.Offset + len(sp.Bytes)
+		}
+		if rest = append(rest, s[prev:]...); !utf8.Valid(rest) {
+			t.Errorf("ValidateUTF8(%q): %q is still invalid without the spans", s, rest)
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	tests := []struct {
+		name, got, want string
+	}{
+		{"Substr", ustr.Substr("Hi 😊 there", 3, 4), "😊"},
+		{"Substr out of range", ustr.Substr("abc", -2, 10), "abc"},
+		{"Substr empty range", ustr.Substr("abc", 2, 1), ""},
+		{"Reverse", ustr.Reverse("ab🇫🇷é"), "é🇫🇷ba"},
+		{"Reverse invalid", ustr.Reverse("a\xffb"), "b\xffa"},
+		{"Truncate", ustr.Truncate("日本語です", 7, "…"), "日本語…"},
+		{"Truncate fits", ustr.Truncate("Go", 2, "…"), "Go"},
+		{"Truncate to the ellipsis", ustr.Truncate("日本", 1, "…"), "…"},
+		{"PadLeft", ustr.PadLeft("日本", 6, '.'), "..日本"},
+		{"PadRight wide pad", ustr.PadRight("Go", 5, '日'), "Go日 "},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+	if w := ustr.Width("a日😊́"); w != 5 {
+		t.Errorf("Width = %d, want 5", w)
+	}
+	err := ustr.ValidateUTF8("ok\xff\xfeok\x80")
+	var inv *ustr.InvalidUTF8Error
+	if !errors.As(err, &inv) || len(inv.Spans) != 2 || inv.Spans[0].Offset != 2 || inv.Spans[1].Offset != 6 {
+		t.Errorf("ValidateUTF8 = %v", err)
+	}
+}
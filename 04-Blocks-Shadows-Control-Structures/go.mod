@@ -1,3 +1,12 @@
 module github.com/maevadevs/Go-Learning/Blocks-Shadows-Control-Structures
 
 go 1.26.1
+
+require (
+	github.com/rivo/uniseg v0.4.7
+	golang.org/x/mod v0.41.0
+	golang.org/x/text v0.40.0
+	golang.org/x/tools v0.51.0
+)
+
+require golang.org/x/sync v0.23.0 // indirect
@@ -0,0 +1,448 @@
+// Package cfgviz builds the control-flow graph of Go functions and renders it
+// in Graphviz DOT or Mermaid.
+//
+// A block is a run of statements that always execute in sequence. Edges record
+// why control moves from one block to the next: falling through, a condition,
+// a switch case, or a break, continue, goto, or fallthrough. Branches to a label,
+// such as `break switchLoop` or `continue outerLoop`, are highlighted.
+//
+// The graph follows the approach of golang.org/x/tools/go/cfg, which leaves
+// branch statements out of its blocks and so cannot tell which edges they create.
+package cfgviz
+
+import (
+	"bytes"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"strings"
+)
+
+// Why control moves along an edge.
+type EdgeKind int
+
+const (
+	// Falling through to the next block
+	Flow EdgeKind = iota
+	// The true or false outcome of a condition
+	Cond
+	// A switch, type switch, or select case, or the default case
+	Case
+	// A break, continue, goto, or fallthrough statement
+	Branch
+	// A return statement, to the exit block
+	Return
+)
+
+// A basic block.
+type Block struct {
+	Index int
+	// What gave rise to the block: entry, exit, if.then, for.body, label skip...
+	Kind string
+	// The line where the block starts
+	Line int
+	// The source of the statements, one per line
+	Stmts []string
+	// Whether the block can be reached from the entry
+	Live bool
+}
+
+// A control-flow edge between two blocks.
+type Edge struct {
+	From, To int
+	Kind     EdgeKind
+	// Printed on the edge: "true", "case 1, 2", "break switchLoop"...
+	Text string
+	// Whether the edge comes from a branch statement with a label
+	Labeled bool
+}
+
+// The control-flow graph of one function.
+type Graph struct {
+	Name   string
+	Blocks []*Block
+	Edges  []Edge
+}
+
+// Build the graphs of all the functions and methods of a file, in source order.
+// Function literals are part of the statements that contain them.
+func File(fset *token.FileSet, file *ast.File) []*Graph {
+	var graphs []*Graph
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body != nil {
+			graphs = append(graphs, Func(fset, fn))
+		}
+	}
+	return graphs
+}
+
+// Build the graph of a single function.
+func Func(fset *token.FileSet, fn *ast.FuncDecl) *Graph {
+	name := fn.Name.Name
+	if fn.Recv != nil && len(fn.Recv.List) > 0 {
+		name = "(" + nodeString(fset, fn.Recv.List[0].Type) + ")." + name
+	}
+	b := &builder{
+		fset:   fset,
+		g:      &Graph{Name: name},
+		labels: map[string]*labelTarget{},
+	}
+	entry := b.newBlock("entry", fn.Pos())
+	b.exit = b.newBlock("exit", fn.Body.Rbrace)
+	b.current = entry
+	b.stmtList(fn.Body.List)
+	b.jump(b.exit, Flow, "")
+	b.g.simplify()
+	return b.g
+}
+
+// The blocks that break, continue, and fallthrough jump to.
+// The innermost statement is first.
+type targets struct {
+	tail      *targets
+	brk, cont *Block
+	fall      *Block
+}
+
+// The blocks of a label: the labeled statement, and its break and continue targets.
+type labelTarget struct {
+	block     *Block
+	brk, cont *Block
+}
+
+// Build the graph of a function body.
+type builder struct {
+	fset    *token.FileSet
+	g       *Graph
+	current *Block
+	exit    *Block
+	targets *targets
+	labels  map[string]*labelTarget
+}
+
+// Create a block.
+func (b *builder) newBlock(kind string, pos token.Pos) *Block {
+	blk := &Block{Index: len(b.g.Blocks), Kind: kind, Line: b.fset.Position(pos).Line}
+	b.g.Blocks = append(b.g.Blocks, blk)
+	return blk
+}
+
+// Add an edge from the current block.
+func (b *builder) edge(to *Block, kind EdgeKind, text string) {
+	b.g.Edges = append(b.g.Edges, Edge{From: b.current.Index, To: to.Index, Kind: kind, Text: text})
+}
+
+// Jump from the current block to another, then continue in a new unreachable block.
+func (b *builder) jump(to *Block, kind EdgeKind, text string) {
+	b.edge(to, kind, text)
+	b.current = b.newBlock("unreachable", token.NoPos)
+}
+
+// Add a statement or expression to the current block.
+func (b *builder) add(n ast.Node) {
+	b.addText(nodeString(b.fset, n))
+}
+
+// Add a line of text to the current block.
+func (b *builder) addText(s string) {
+	b.current.Stmts = append(b.current.Stmts, s)
+}
+
+// Return the block of a label, creating it for a goto that comes before the label.
+func (b *builder) label(name string, pos token.Pos) *labelTarget {
+	lt, ok := b.labels[name]
+	if !ok {
+		lt = &labelTarget{block: b.newBlock("label "+name, pos)}
+		b.labels[name] = lt
+	}
+	return lt
+}
+
+// Add the statements of a list, in order.
+func (b *builder) stmtList(list []ast.Stmt) {
+	for _, s := range list {
+		b.stmt(s, nil)
+	}
+}
+
+// Add a statement. lt is the label of the statement, if any.
+func (b *builder) stmt(s ast.Stmt, lt *labelTarget) {
+	switch s := s.(type) {
+	case *ast.BlockStmt:
+		b.stmtList(s.List)
+	case *ast.LabeledStmt:
+		b.labeledStmt(s)
+	case *ast.ReturnStmt:
+		b.add(s)
+		b.jump(b.exit, Return, "")
+	case *ast.BranchStmt:
+		b.branchStmt(s)
+	case *ast.IfStmt:
+		b.ifStmt(s)
+	case *ast.ForStmt:
+		b.forStmt(s, lt)
+	case *ast.RangeStmt:
+		b.rangeStmt(s, lt)
+	case *ast.SwitchStmt:
+		b.switchStmt(s, lt)
+	case *ast.TypeSwitchStmt:
+		b.typeSwitchStmt(s, lt)
+	case *ast.SelectStmt:
+		b.selectStmt(s, lt)
+	case *ast.EmptyStmt:
+	default:
+		// Declarations, assignments, calls, go, defer, send, ++ and --
+		b.add(s)
+	}
+}
+
+// Start a block at the label, so that goto can jump to it.
+func (b *builder) labeledStmt(s *ast.LabeledStmt) {
+	lt := b.label(s.Label.Name, s.Pos())
+	lt.block.Line = b.fset.Position(s.Pos()).Line
+	b.jump(lt.block, Flow, "")
+	b.current = lt.block
+	b.stmt(s.Stmt, lt)
+}
+
+// Jump to the target of a break, continue, goto, or fallthrough.
+func (b *builder) branchStmt(s *ast.BranchStmt) {
+	var to *Block
+	switch s.Tok {
+	case token.BREAK:
+		if s.Label != nil {
+			to = b.label(s.Label.Name, s.Pos()).brk
+		}
+		for t := b.targets; t != nil && to == nil; t = t.tail {
+			to = t.brk
+		}
+	case token.CONTINUE:
+		if s.Label != nil {
+			to = b.label(s.Label.Name, s.Pos()).cont
+		}
+		for t := b.targets; t != nil && to == nil; t = t.tail {
+			to = t.cont
+		}
+	case token.GOTO:
+		to = b.label(s.Label.Name, s.Pos()).block
+	case token.FALLTHROUGH:
+		for t := b.targets; t != nil && to == nil; t = t.tail {
+			to = t.fall
+		}
+	}
+	if to == nil {
+		// Not valid Go: keep the statement in the block
+		b.add(s)
+		return
+	}
+	b.edge(to, Branch, nodeString(b.fset, s))
+	b.g.Edges[len(b.g.Edges)-1].Labeled = s.Label != nil
+	b.current = b.newBlock("unreachable", token.NoPos)
+}
+
+// Run fn with the targets of an enclosing loop, switch, or select.
+func (b *builder) withTargets(t *targets, fn func()) {
+	t.tail = b.targets
+	b.targets = t
+	fn()
+	b.targets = t.tail
+}
+
+func (b *builder) ifStmt(s *ast.IfStmt) {
+	if s.Init != nil {
+		b.add(s.Init)
+	}
+	b.addText("if " + nodeString(b.fset, s.Cond))
+	then := b.newBlock("if.then", s.Body.Pos())
+	done := b.newBlock("if.done", s.End())
+	els := done
+	if s.Else != nil {
+		els = b.newBlock("if.else", s.Else.Pos())
+	}
+	b.edge(then, Cond, "true")
+	b.edge(els, Cond, "false")
+
+	b.current = then
+	b.stmt(s.Body, nil)
+	b.jump(done, Flow, "")
+	if s.Else != nil {
+		b.current = els
+		b.stmt(s.Else, nil)
+		b.jump(done, Flow, "")
+	}
+	b.current = done
+}
+
+func (b *builder) forStmt(s *ast.ForStmt, lt *labelTarget) {
+	if s.Init != nil {
+		b.add(s.Init)
+	}
+	loop := b.newBlock("for.loop", s.Pos())
+	body := b.newBlock("for.body", s.Body.Pos())
+	done := b.newBlock("for.done", s.End())
+	cont := loop
+	if s.Post != nil {
+		cont = b.newBlock("for.post", s.Post.Pos())
+	}
+	if lt != nil {
+		lt.brk, lt.cont = done, cont
+	}
+	b.jump(loop, Flow, "")
+
+	b.current = loop
+	if s.Cond != nil {
+		b.addText("for " + nodeString(b.fset, s.Cond))
+		b.edge(body, Cond, "true")
+		b.edge(done, Cond, "false")
+	} else {
+		b.addText("for")
+		b.edge(body, Flow, "")
+	}
+
+	b.current = body
+	b.withTargets(&targets{brk: done, cont: cont}, func() { b.stmt(s.Body, nil) })
+	b.jump(cont, Flow, "")
+
+	if s.Post != nil {
+		b.current = cont
+		b.add(s.Post)
+		b.jump(loop, Flow, "")
+	}
+	b.current = done
+}
+
+func (b *builder) rangeStmt(s *ast.RangeStmt, lt *labelTarget) {
+	loop := b.newBlock("range.loop", s.Pos())
+	body := b.newBlock("range.body", s.Body.Pos())
+	done := b.newBlock("range.done", s.End())
+	if lt != nil {
+		lt.brk, lt.cont = done, loop
+	}
+	b.jump(loop, Flow, "")
+
+	b.current = loop
+	header := "range " + nodeString(b.fset, s.X)
+	if s.Key != nil {
+		vars := nodeString(b.fset, s.Key)
+		if s.Value != nil {
+			vars += ", " + nodeString(b.fset, s.Value)
+		}
+		header = "for " + vars + " " + s.Tok.String() + " " + header
+	} else {
+		header = "for " + header
+	}
+	b.addText(header)
+	b.edge(body, Cond, "next")
+	b.edge(done, Cond, "done")
+
+	b.current = body
+	b.withTargets(&targets{brk: done, cont: loop}, func() { b.stmt(s.Body, nil) })
+	b.jump(loop, Flow, "")
+	b.current = done
+}
+
+func (b *builder) switchStmt(s *ast.SwitchStmt, lt *labelTarget) {
+	if s.Init != nil {
+		b.add(s.Init)
+	}
+	header := "switch"
+	if s.Tag != nil {
+		header += " " + nodeString(b.fset, s.Tag)
+	}
+	b.addText(header)
+	b.caseClauses(s.Body, lt, "switch", true, func(c ast.Stmt) ([]ast.Node, []ast.Stmt) {
+		cc := c.(*ast.CaseClause)
+		return exprNodes(cc.List), cc.Body
+	})
+}
+
+func (b *builder) typeSwitchStmt(s *ast.TypeSwitchStmt, lt *labelTarget) {
+	if s.Init != nil {
+		b.add(s.Init)
+	}
+	b.addText("switch " + nodeString(b.fset, s.Assign))
+	b.caseClauses(s.Body, lt, "typeswitch", false, func(c ast.Stmt) ([]ast.Node, []ast.Stmt) {
+		cc := c.(*ast.CaseClause)
+		return exprNodes(cc.List), cc.Body
+	})
+}
+
+func (b *builder) selectStmt(s *ast.SelectStmt, lt *labelTarget) {
+	b.addText("select")
+	b.caseClauses(s.Body, lt, "select", false, func(c ast.Stmt) ([]ast.Node, []ast.Stmt) {
+		cc := c.(*ast.CommClause)
+		if cc.Comm == nil {
+			return nil, cc.Body
+		}
+		return []ast.Node{cc.Comm}, cc.Body
+	})
+}
+
+// Add an edge from the current block to the body of every case,
+// and to the end of the statement if no case can match.
+func (b *builder) caseClauses(body *ast.BlockStmt, lt *labelTarget, kind string, canFallthrough bool, clause func(ast.Stmt) ([]ast.Node, []ast.Stmt)) {
+	done := b.newBlock(kind+".done", body.Rbrace)
+	if lt != nil {
+		lt.brk = done
+	}
+	bodies := make([]*Block, len(body.List))
+	for i, c := range body.List {
+		bodies[i] = b.newBlock(kind+".body", c.Pos())
+	}
+	hasDefault := false
+	for i, c := range body.List {
+		list, _ := clause(c)
+		if list == nil {
+			hasDefault = true
+			b.edge(bodies[i], Case, "default")
+			continue
+		}
+		texts := make([]string, len(list))
+		for j, n := range list {
+			texts[j] = nodeString(b.fset, n)
+		}
+		b.edge(bodies[i], Case, "case "+strings.Join(texts, ", "))
+	}
+	// Without a default case, a switch can match no case. A select waits instead.
+	if !hasDefault && kind != "select" {
+		b.edge(done, Case, "no case")
+	}
+
+	for i, c := range body.List {
+		_, stmts := clause(c)
+		t := &targets{brk: done}
+		if canFallthrough && i+1 < len(bodies) {
+			t.fall = bodies[i+1]
+		}
+		b.current = bodies[i]
+		b.withTargets(t, func() { b.stmtList(stmts) })
+		b.jump(done, Flow, "")
+	}
+	b.current = done
+}
+
+// Convert a list of expressions to a list of nodes.
+func exprNodes(list []ast.Expr) []ast.Node {
+	if list == nil {
+		return nil
+	}
+	nodes := make([]ast.Node, len(list))
+	for i, e := range list {
+		nodes[i] = e
+	}
+	return nodes
+}
+
+// Print a node as Go source, on a single line.
+// A node on several lines, such as a function literal, is cut after its first line.
+func nodeString(fset *token.FileSet, n ast.Node) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, n); err != nil {
+		return "?"
+	}
+	s := buf.String()
+	if first, _, found := strings.Cut(s, "\n"); found {
+		return first + " …"
+	}
+	return s
+}
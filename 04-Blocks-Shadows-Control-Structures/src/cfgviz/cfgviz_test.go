@@ -0,0 +1,68 @@
+package cfgviz_test
+
+import (
+	"bytes"
+	"flag"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/maevadevs/Go-Learning/Blocks-Shadows-Control-Structures/src/cfgviz"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+// Compare the output with a golden file in testdata, or rewrite it with -update.
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s, run go test -update to see the diff:\n%s", path, got)
+	}
+}
+
+// Build the graphs of testdata/funcs.go.
+func graphs(t *testing.T) []*cfgviz.Graph {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filepath.Join("testdata", "funcs.go"), nil, parser.SkipObjectResolution)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfgviz.File(fset, file)
+}
+
+func TestWriteDOT(t *testing.T) {
+	var buf bytes.Buffer
+	if err := cfgviz.WriteDOT(&buf, graphs(t)); err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "funcs.dot.golden", buf.Bytes())
+	// Graphviz keeps only the last of repeated attributes: a dead label block must not lose its fill
+	for line := range strings.Lines(buf.String()) {
+		if strings.Count(line, "style=") > 1 {
+			t.Errorf("repeated style: %s", line)
+		}
+	}
+}
+
+func TestWriteMermaid(t *testing.T) {
+	var buf bytes.Buffer
+	if err := cfgviz.WriteMermaid(&buf, graphs(t)); err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "funcs.mmd.golden", buf.Bytes())
+}
@@ -0,0 +1,153 @@
+package cfgviz
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// The title of a block: "B3 for.body (line 140)"
+func (blk *Block) title() string {
+	if blk.Line == 0 {
+		return fmt.Sprintf("B%d %s", blk.Index, blk.Kind)
+	}
+	return fmt.Sprintf("B%d %s (line %d)", blk.Index, blk.Kind, blk.Line)
+}
+
+// Write the graphs in Graphviz DOT, one cluster per function.
+//
+//	go run ./src/cmd/cfgviz src/main.go | dot -Tsvg > cfg.svg
+func WriteDOT(w io.Writer, graphs []*Graph) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph cfg {")
+	fmt.Fprintln(bw, `  node [shape=box, fontname="monospace", fontsize=10];`)
+	fmt.Fprintln(bw, `  edge [fontname="monospace", fontsize=9];`)
+	for f, g := range graphs {
+		fmt.Fprintf(bw, "  subgraph cluster_%d {\n", f)
+		fmt.Fprintf(bw, "    label=%s;\n", dotQuote(g.Name))
+		for _, blk := range g.Blocks {
+			label := dotEscape(blk.title()) + `\l`
+			for _, s := range blk.Stmts {
+				label += dotEscape("  "+s) + `\l`
+			}
+			fmt.Fprintf(bw, "    f%d_b%d%s;\n", f, blk.Index, dotAttrs(blockAttrs(blk, label)))
+		}
+		for _, e := range g.Edges {
+			var attrs []string
+			if e.Text != "" {
+				attrs = append(attrs, "label="+dotQuote(e.Text))
+			}
+			switch {
+			case e.Labeled:
+				attrs = append(attrs, "color=red", "fontcolor=red", "penwidth=2")
+			case e.Kind == Branch:
+				attrs = append(attrs, "style=dashed")
+			case e.Kind == Return:
+				attrs = append(attrs, "color=gray")
+			}
+			fmt.Fprintf(bw, "    f%d_b%d -> f%d_b%d%s;\n", f, e.From, f, e.To, dotAttrs(attrs))
+		}
+		fmt.Fprintln(bw, "  }")
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// Return the DOT attributes of a block. The styles are merged into one attribute:
+// a dead label block is style="filled,dashed".
+func blockAttrs(blk *Block, label string) []string {
+	attrs := []string{`label="` + label + `"`}
+	var styles []string
+	switch {
+	case blk.Kind == "entry" || blk.Kind == "exit":
+		attrs = append(attrs, "shape=oval")
+	case strings.HasPrefix(blk.Kind, "label "):
+		styles = append(styles, "filled")
+		attrs = append(attrs, `fillcolor="#fff3c4"`)
+	}
+	if !blk.Live {
+		styles = append(styles, "dashed")
+		attrs = append(attrs, "color=gray", "fontcolor=gray")
+	}
+	if len(styles) > 0 {
+		attrs = append(attrs, "style="+dotQuote(strings.Join(styles, ",")))
+	}
+	return attrs
+}
+
+// Format DOT attributes: " [a=1, b=2]", or nothing.
+func dotAttrs(attrs []string) string {
+	if len(attrs) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(attrs, ", ") + "]"
+}
+
+// Escape a string for a DOT label, without the quotes.
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
+// Quote a string for DOT.
+func dotQuote(s string) string {
+	return `"` + dotEscape(s) + `"`
+}
+
+// Write the graphs as a Mermaid flowchart, one subgraph per function.
+// The output can be pasted into a ```mermaid block of a Markdown file.
+func WriteMermaid(w io.Writer, graphs []*Graph) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "flowchart TD")
+	// linkStyle refers to the edges by their index in the whole chart
+	edgeIndex := 0
+	var labeled, dead []string
+	for f, g := range graphs {
+		fmt.Fprintf(bw, "  subgraph f%d[\"%s\"]\n", f, mermaidEscape(g.Name))
+		for _, blk := range g.Blocks {
+			lines := []string{"<b>" + mermaidEscape(blk.title()) + "</b>"}
+			for _, s := range blk.Stmts {
+				lines = append(lines, mermaidEscape(s))
+			}
+			id := fmt.Sprintf("f%d_b%d", f, blk.Index)
+			left, right := `["`, `"]`
+			if blk.Kind == "entry" || blk.Kind == "exit" {
+				left, right = `(["`, `"])`
+			}
+			fmt.Fprintf(bw, "    %s%s%s%s\n", id, left, strings.Join(lines, "<br/>"), right)
+			if !blk.Live {
+				dead = append(dead, id)
+			}
+		}
+		for _, e := range g.Edges {
+			arrow := "-->"
+			switch {
+			case e.Labeled:
+				arrow = "==>"
+				labeled = append(labeled, fmt.Sprint(edgeIndex))
+			case e.Kind == Branch:
+				arrow = "-.->"
+			}
+			text := ""
+			if e.Text != "" {
+				text = `|"` + mermaidEscape(e.Text) + `"|`
+			}
+			fmt.Fprintf(bw, "    f%d_b%d %s%s f%d_b%d\n", f, e.From, arrow, text, f, e.To)
+			edgeIndex++
+		}
+		fmt.Fprintln(bw, "  end")
+	}
+	if len(labeled) > 0 {
+		fmt.Fprintf(bw, "  linkStyle %s stroke:red,stroke-width:2px,color:red\n", strings.Join(labeled, ","))
+	}
+	if len(dead) > 0 {
+		fmt.Fprintln(bw, "  classDef dead stroke-dasharray:4,color:gray")
+		fmt.Fprintf(bw, "  class %s dead\n", strings.Join(dead, ","))
+	}
+	return bw.Flush()
+}
+
+// Escape a string for a quoted Mermaid label.
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;", "|", "#124;").Replace(s)
+}
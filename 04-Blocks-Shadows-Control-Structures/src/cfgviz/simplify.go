@@ -0,0 +1,107 @@
+package cfgviz
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Remove the empty blocks that only pass control on, and the empty blocks that cannot be reached.
+// Entry, exit, and label blocks are always kept. Blocks are renumbered in creation order.
+func (g *Graph) simplify() {
+	removed := make([]bool, len(g.Blocks))
+	// Redirecting is transitive, so a single pass collapses chains of empty blocks
+	for _, blk := range g.Blocks {
+		if !kept(blk) && g.bypass(blk) {
+			removed[blk.Index] = true
+		}
+	}
+	g.markLive()
+	for _, blk := range g.Blocks {
+		if !blk.Live && !kept(blk) {
+			removed[blk.Index] = true
+		}
+	}
+	g.renumber(removed)
+}
+
+// Report whether a block is kept even when it does nothing.
+func kept(blk *Block) bool {
+	return blk.Kind == "entry" || blk.Kind == "exit" || strings.HasPrefix(blk.Kind, "label ") || len(blk.Stmts) > 0
+}
+
+// If the block has a single plain successor, move its incoming edges to the successor,
+// remove its outgoing edge, and report true.
+func (g *Graph) bypass(blk *Block) bool {
+	out := -1
+	for i, e := range g.Edges {
+		if e.From != blk.Index {
+			continue
+		}
+		if out >= 0 {
+			return false
+		}
+		out = i
+	}
+	if out < 0 || g.Edges[out].Kind != Flow || g.Edges[out].To == blk.Index {
+		return false
+	}
+	to := g.Edges[out].To
+	g.Edges = append(g.Edges[:out], g.Edges[out+1:]...)
+	for i := range g.Edges {
+		if g.Edges[i].To == blk.Index {
+			g.Edges[i].To = to
+		}
+	}
+	return true
+}
+
+// Renumber the blocks that are left, and drop the edges of removed blocks.
+func (g *Graph) renumber(removed []bool) {
+	index := make([]int, len(g.Blocks))
+	var blocks []*Block
+	for _, blk := range g.Blocks {
+		if !removed[blk.Index] {
+			index[blk.Index] = len(blocks)
+			blk.Index = len(blocks)
+			blocks = append(blocks, blk)
+		}
+	}
+	var edges []Edge
+	for _, e := range g.Edges {
+		if !removed[e.From] && !removed[e.To] {
+			e.From, e.To = index[e.From], index[e.To]
+			edges = append(edges, e)
+		}
+	}
+	g.Blocks, g.Edges = blocks, edges
+}
+
+// Mark the blocks that can be reached from the entry block.
+func (g *Graph) markLive() {
+	succs := make([][]int, len(g.Blocks))
+	for _, e := range g.Edges {
+		succs[e.From] = append(succs[e.From], e.To)
+	}
+	stack := []int{0}
+	g.Blocks[0].Live = true
+	for len(stack) > 0 {
+		i := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, s := range succs[i] {
+			if !g.Blocks[s].Live {
+				g.Blocks[s].Live = true
+				stack = append(stack, s)
+			}
+		}
+	}
+}
+
+// Keep at most n statements per block, replacing the rest with "… k more".
+// Long sequential sections, like those of a chapter's main, then fit on screen.
+func (g *Graph) Truncate(n int) {
+	for _, blk := range g.Blocks {
+		if extra := len(blk.Stmts) - n; n > 0 && extra > 0 {
+			blk.Stmts = append(blk.Stmts[:n:n], fmt.Sprintf("… %d more", extra))
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package funcs
+
+import "fmt"
+
+func loops(rows [][]int) {
+outer:
+	for _, row := range rows {
+		for _, v := range row {
+			if v < 0 {
+				continue outer
+			}
+			if v == 0 {
+				break
+			}
+			fmt.Println(v)
+		}
+	}
+}
+
+func cases(n int) string {
+	switch {
+	case n < 0:
+		return "negative"
+	case n == 0:
+		fallthrough
+	case n < 10:
+		return "small"
+	}
+	return "large"
+}
+
+// The label block after the return is dead: it is drawn filled and dashed
+func dead() {
+	fmt.Println("start")
+	return
+skip:
+	fmt.Println("never")
+	goto skip
+}
+
+type T struct{}
+
+func (t *T) quote(s string) string {
+	return `"` + s + `" <x|y>`
+}
@@ -0,0 +1,64 @@
+// Command cfgviz prints the control-flow graph of the functions of Go source files.
+//
+//	go run ./src/cmd/cfgviz -func main src/main.go | dot -Tsvg > main.svg
+//	go run ./src/cmd/cfgviz -format mermaid -func main -stmts 3 src/main.go
+//
+// Branches to a label, such as `break switchLoop`, are drawn in red.
+package main
+
+import (
+	"flag"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/maevadevs/Go-Learning/Blocks-Shadows-Control-Structures/src/cfgviz"
+)
+
+// This is the main entry of the application.
+func main() {
+	format := flag.String("format", "dot", "output format: dot or mermaid")
+	funcs := flag.String("func", "", "comma-separated names of the functions to draw, all by default")
+	stmts := flag.Int("stmts", 0, "maximum number of statements shown per block, 0 for all")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		log.Fatal("usage: cfgviz [-format dot|mermaid] [-func names] file.go...")
+	}
+
+	write := cfgviz.WriteDOT
+	switch *format {
+	case "dot":
+	case "mermaid":
+		write = cfgviz.WriteMermaid
+	default:
+		log.Fatalf("unknown format %q: use dot or mermaid", *format)
+	}
+
+	var names []string
+	if *funcs != "" {
+		names = strings.Split(*funcs, ",")
+	}
+	fset := token.NewFileSet()
+	var graphs []*cfgviz.Graph
+	for _, name := range flag.Args() {
+		file, err := parser.ParseFile(fset, name, nil, parser.SkipObjectResolution)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, g := range cfgviz.File(fset, file) {
+			if names == nil || slices.Contains(names, g.Name) {
+				g.Truncate(*stmts)
+				graphs = append(graphs, g)
+			}
+		}
+	}
+	if len(graphs) == 0 {
+		log.Fatal("no function to draw")
+	}
+	if err := write(os.Stdout, graphs); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,94 @@
+// Command complexity measures every function of every module of the go.work workspace,
+// and fails when a function goes over a threshold.
+//
+//	go run ./src/cmd/complexity -max-cyclo 15 -max-nesting 4
+//	go run ./src/cmd/complexity -max-cyclo 15 -exclude '*/src/main.go:main'
+//	go run ./src/cmd/complexity -json bin/complexity.json
+//
+// It exits with status 1 when a threshold is exceeded.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/maevadevs/Go-Learning/Blocks-Shadows-Control-Structures/src/complexity"
+)
+
+// This is the main entry of the application.
+func main() {
+	work := flag.String("work", "", "go.work file, found from the current directory by default")
+	maxCyclo := flag.Int("max-cyclo", 0, "maximum cyclomatic complexity, 0 for no limit")
+	maxNesting := flag.Int("max-nesting", 0, "maximum nesting depth, 0 for no limit")
+	maxLines := flag.Int("max-lines", 0, "maximum function length in lines, 0 for no limit")
+	jsonFile := flag.String("json", "", "also write the full report as JSON to this file, - for standard output")
+	top := flag.Int("top", 20, "number of functions listed, 0 for all")
+	exclude := flag.String("exclude", "", "comma-separated file:name patterns of functions not checked, like '*/src/main.go:main'")
+	flag.Parse()
+
+	var patterns []string
+	for pat := range strings.SplitSeq(*exclude, ",") {
+		if pat = strings.TrimSpace(pat); pat != "" {
+			patterns = append(patterns, pat)
+		}
+	}
+
+	if *work == "" {
+		found, err := complexity.FindWork(".")
+		if err != nil {
+			log.Fatal(err)
+		}
+		*work = found
+	}
+	rep, err := complexity.Analyze(*work)
+	if err != nil {
+		log.Fatal(err)
+	}
+	rep.Summarize()
+	violations, err := rep.Check(complexity.Thresholds{
+		Cyclomatic: *maxCyclo,
+		Nesting:    *maxNesting,
+		Lines:      *maxLines,
+		Exclude:    patterns,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	switch *jsonFile {
+	case "":
+		if err := rep.WriteTable(os.Stdout, *top); err != nil {
+			log.Fatal(err)
+		}
+	case "-":
+		if err := rep.WriteJSON(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		if err := rep.WriteTable(os.Stdout, *top); err != nil {
+			log.Fatal(err)
+		}
+		fl, err := os.Create(*jsonFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := rep.WriteJSON(fl); err != nil {
+			log.Fatal(err)
+		}
+		if err := fl.Close(); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if len(violations) > 0 {
+		fmt.Fprintln(os.Stderr)
+		for _, v := range violations {
+			fmt.Fprintln(os.Stderr, v)
+		}
+		fmt.Fprintf(os.Stderr, "%d thresholds exceeded\n", len(violations))
+		os.Exit(1)
+	}
+}
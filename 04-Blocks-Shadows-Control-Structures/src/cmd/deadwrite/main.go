@@ -0,0 +1,22 @@
+// Command deadwrite reports assignments to range loop variables and parameters
+// that can never be observed.
+//
+// Run it standalone, optionally applying the suggested fixes with -fix:
+//
+//	go run ./src/cmd/deadwrite ./...
+//
+// Or through go vet:
+//
+//	go build -o bin/deadwrite ./src/cmd/deadwrite
+//	go vet -vettool=$(pwd)/bin/deadwrite ./...
+package main
+
+import (
+	"github.com/maevadevs/Go-Learning/Blocks-Shadows-Control-Structures/src/deadwrite"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+// This is the main entry of the application.
+func main() {
+	singlechecker.Main(deadwrite.Analyzer)
+}
@@ -0,0 +1,80 @@
+// Command guess is an interactive higher/lower number-guessing game.
+//
+//	go run ./src/cmd/guess -difficulty hard -name Mary
+//
+// Use -seed to replay the same secret numbers.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/maevadevs/Go-Learning/Blocks-Shadows-Control-Structures/src/guess"
+)
+
+// This is the main entry of the application.
+func main() {
+	difficulty := flag.String("difficulty", "normal", "difficulty level: easy, normal, or hard")
+	seed := flag.Uint64("seed", 0, "seed of the secret number, random if not set")
+	name := flag.String("name", "player", "name saved with the score")
+	scores := flag.String("scores", defaultScoreFile(), "score file")
+	flag.Parse()
+
+	diff, err := guess.ParseDifficulty(*difficulty)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// Any value, 0 included, replays a game: only a missing flag means random
+	seeded := false
+	flag.Visit(func(f *flag.Flag) { seeded = seeded || f.Name == "seed" })
+	if !seeded {
+		*seed = rand.Uint64()
+	}
+	game := guess.Game{
+		Difficulty: diff,
+		Rand:       rand.New(rand.NewPCG(*seed, *seed)),
+		In:         os.Stdin,
+		Out:        os.Stdout,
+	}
+
+	res, err := game.Play()
+	if errors.Is(err, guess.ErrQuit) {
+		fmt.Println("Good bye! The number was", res.Secret)
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !res.Won {
+		return
+	}
+
+	rank, err := guess.SaveScore(*scores, guess.Score{
+		Name:       *name,
+		Difficulty: diff.Name,
+		Points:     res.Points,
+		Attempts:   res.Attempts,
+		Date:       time.Now().UTC(),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if rank > 0 {
+		fmt.Printf("New high score: rank %d in %s\n", rank, *scores)
+	}
+}
+
+// Return the score file in the user's home directory, or in the current directory.
+func defaultScoreFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".guess-scores.json"
+	}
+	return filepath.Join(home, ".guess-scores.json")
+}
@@ -0,0 +1,51 @@
+// Command runes lists the grapheme clusters, runes, and bytes of a string.
+//
+//	go run ./src/cmd/runes "Hi π!"
+//	go run ./src/cmd/runes -json -file src/textfiles/runes.txt
+//
+// Without a string or a file, it reads standard input.
+package main
+
+import (
+	"flag"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/maevadevs/Go-Learning/Blocks-Shadows-Control-Structures/src/runeinfo"
+)
+
+// This is the main entry of the application.
+func main() {
+	file := flag.String("file", "", "inspect the content of a file")
+	asJSON := flag.Bool("json", false, "print JSON instead of a table")
+	flag.Parse()
+
+	var s string
+	switch {
+	case *file != "":
+		data, err := os.ReadFile(*file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		s = string(data)
+	case flag.NArg() > 0:
+		s = strings.Join(flag.Args(), " ")
+	default:
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			log.Fatal(err)
+		}
+		s = string(data)
+	}
+
+	rep := runeinfo.Inspect(s)
+	write := rep.WriteTable
+	if *asJSON {
+		write = rep.WriteJSON
+	}
+	if err := write(os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
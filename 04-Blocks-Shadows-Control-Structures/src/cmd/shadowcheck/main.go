@@ -0,0 +1,22 @@
+// Command shadowcheck reports declarations that shadow variables, imported packages
+// or universe identifiers.
+//
+// Run it standalone:
+//
+//	go run ./src/cmd/shadowcheck ./...
+//
+// Or through go vet:
+//
+//	go build -o bin/shadowcheck ./src/cmd/shadowcheck
+//	go vet -vettool=$(pwd)/bin/shadowcheck ./...
+package main
+
+import (
+	"github.com/maevadevs/Go-Learning/Blocks-Shadows-Control-Structures/src/shadowcheck"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+// This is the main entry of the application.
+func main() {
+	singlechecker.Main(shadowcheck.Analyzer)
+}
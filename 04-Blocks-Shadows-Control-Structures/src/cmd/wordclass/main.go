@@ -0,0 +1,60 @@
+// Command wordclass classifies the words of text files and prints a histogram per dimension.
+//
+//	go run ./src/cmd/wordclass readme.md
+//	go run ./src/cmd/wordclass -config src/textfiles/wordclass.json readme.md
+//
+// Without a config, words are classified by rune length only.
+// Without files, it reads standard input.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/maevadevs/Go-Learning/Blocks-Shadows-Control-Structures/src/wordclass"
+)
+
+// This is the main entry of the application.
+func main() {
+	config := flag.String("config", "", "JSON file with the classification rules")
+	width := flag.Int("width", 40, "width of the longest histogram bar")
+	flag.Parse()
+
+	c, err := wordclass.New(wordclass.DefaultConfig.Dimensions...)
+	if *config != "" {
+		c, err = wordclass.LoadFile(*config)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var readers []io.Reader
+	for _, name := range flag.Args() {
+		fl, err := os.Open(name)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer fl.Close()
+		readers = append(readers, fl)
+	}
+	if len(readers) == 0 {
+		readers = append(readers, os.Stdin)
+	}
+
+	var readErr error
+	hists := c.Count(wordclass.Words(io.MultiReader(readers...), &readErr))
+	if readErr != nil {
+		log.Fatal(readErr)
+	}
+	for i, h := range hists {
+		if i > 0 {
+			fmt.Println()
+		}
+		if err := h.Write(os.Stdout, *width); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+// Package complexity measures the functions of Go source files:
+// cyclomatic complexity, maximum nesting depth, and length in lines.
+//
+// Cyclomatic complexity is 1 plus the number of decision points:
+// if, for, range, each non-default case, && and ||.
+// Nesting depth counts the enclosing if, for, switch, select, and function literals;
+// an else if stays at the depth of its if.
+// Function literals are measured as part of the function that contains them.
+package complexity
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// The measures of a single function.
+type Func struct {
+	// Module path, from go.mod
+	Module string `json:"module"`
+	// File path, relative to the directory of go.work
+	File string `json:"file"`
+	// Function name, or (T).Method for a method
+	Name string `json:"name"`
+	Line int    `json:"line"`
+
+	Cyclomatic int `json:"cyclomatic"`
+	Nesting    int `json:"nesting"`
+	Lines      int `json:"lines"`
+}
+
+// Measure the functions and methods of a parsed file, in source order.
+// Module and File are left for the caller to fill.
+func File(fset *token.FileSet, file *ast.File) []Func {
+	var funcs []Func
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		name := fn.Name.Name
+		if fn.Recv != nil && len(fn.Recv.List) > 0 {
+			name = "(" + types.ExprString(fn.Recv.List[0].Type) + ")." + name
+		}
+		funcs = append(funcs, Func{
+			Name:       name,
+			Line:       fset.Position(fn.Pos()).Line,
+			Cyclomatic: Cyclomatic(fn.Body),
+			Nesting:    Nesting(fn.Body),
+			Lines:      fset.Position(fn.End()).Line - fset.Position(fn.Pos()).Line + 1,
+		})
+	}
+	return funcs
+}
+
+// Return the cyclomatic complexity of a function body.
+func Cyclomatic(body *ast.BlockStmt) int {
+	c := 1
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt:
+			c++
+		case *ast.CaseClause:
+			if n.List != nil {
+				c++
+			}
+		case *ast.CommClause:
+			if n.Comm != nil {
+				c++
+			}
+		case *ast.BinaryExpr:
+			if n.Op == token.LAND || n.Op == token.LOR {
+				c++
+			}
+		}
+		return true
+	})
+	return c
+}
+
+// Return the maximum nesting depth of a function body. A body without control structures has depth 0.
+func Nesting(body *ast.BlockStmt) int {
+	deepest := 0
+	ast.Walk(nestVisitor{max: &deepest}, body)
+	return deepest
+}
+
+// Track the nesting depth while walking a function body.
+type nestVisitor struct {
+	depth int
+	max   *int
+}
+
+func (v nestVisitor) Visit(n ast.Node) ast.Visitor {
+	switch n := n.(type) {
+	case *ast.IfStmt:
+		inner := v.enter()
+		if n.Init != nil {
+			ast.Walk(inner, n.Init)
+		}
+		ast.Walk(inner, n.Cond)
+		ast.Walk(inner, n.Body)
+		switch els := n.Else.(type) {
+		case *ast.IfStmt:
+			// else if: a sibling of the if, not a child
+			ast.Walk(v, els)
+		case *ast.BlockStmt:
+			ast.Walk(inner, els)
+		}
+		return nil
+	case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt, *ast.FuncLit:
+		return v.enter()
+	}
+	return v
+}
+
+// Go one level deeper, recording the maximum.
+func (v nestVisitor) enter() nestVisitor {
+	*v.max = max(*v.max, v.depth+1)
+	return nestVisitor{depth: v.depth + 1, max: v.max}
+}
@@ -0,0 +1,186 @@
+package complexity_test
+
+import (
+	"errors"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/maevadevs/Go-Learning/Blocks-Shadows-Control-Structures/src/complexity"
+)
+
+// Measure the functions of a source file, or fail the test.
+func measure(t *testing.T, src string) []complexity.Func {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "src.go", "package p\n"+src, parser.SkipObjectResolution)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return complexity.File(fset, file)
+}
+
+func TestFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		cyclo    int
+		nesting  int
+		funcName string
+	}{
+		{"empty", `func f() {}`, 1, 0, "f"},
+		{"if", `func f(x int) { if x > 0 { x++ } }`, 2, 1, "f"},
+		{"if else", `func f(x int) { if x > 0 { x++ } else { x-- } }`, 2, 1, "f"},
+		{"else if stays at depth 1", `func f(x int) {
+			if x > 0 {
+			} else if x < 0 {
+			} else if x == 0 {
+			} else {
+			}
+		}`, 4, 1, "f"},
+		{"conditions", `func f(a, b, c bool) bool { return a && b || c }`, 3, 0, "f"},
+		{"loops", `func f(s []int) {
+			for i := 0; i < 3; i++ {
+				for range s {
+				}
+			}
+		}`, 3, 2, "f"},
+		{"switch cases", `func f(x int) {
+			switch x {
+			case 1, 2:
+			case 3:
+			default:
+			}
+		}`, 3, 1, "f"},
+		{"type switch", `func f(x any) {
+			switch x.(type) {
+			case int:
+			case string:
+			}
+		}`, 3, 1, "f"},
+		{"select", `func f(c chan int) {
+			select {
+			case <-c:
+			case c <- 1:
+			default:
+			}
+		}`, 3, 1, "f"},
+		{"function literal", `func f() {
+			go func() {
+				if true {
+				}
+			}()
+		}`, 2, 2, "f"},
+		{"deep", `func f(x int) {
+			for {
+				if x > 0 {
+					switch {
+					case x > 1:
+						for range x {
+						}
+					}
+				}
+			}
+		}`, 5, 4, "f"},
+		{"method", `type T struct{}
+		func (t *T) M() {}`, 1, 0, "(*T).M"},
+		{"generic method", `type T[E any] struct{}
+		func (t T[E]) M() {}`, 1, 0, "(T[E]).M"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			funcs := measure(t, tt.src)
+			if len(funcs) != 1 {
+				t.Fatalf("got %d functions, want 1", len(funcs))
+			}
+			fn := funcs[0]
+			if fn.Cyclomatic != tt.cyclo || fn.Nesting != tt.nesting || fn.Name != tt.funcName {
+				t.Errorf("%s: cyclomatic %d, nesting %d, want %s: %d, %d",
+					fn.Name, fn.Cyclomatic, fn.Nesting, tt.funcName, tt.cyclo, tt.nesting)
+			}
+		})
+	}
+
+	funcs := measure(t, "func a() {}\n\nfunc b() {\n\treturn\n}\n")
+	if len(funcs) != 2 || funcs[1].Line != 4 || funcs[1].Lines != 3 {
+		t.Errorf("lines of b: %+v", funcs)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	rep := complexity.Report{Funcs: []complexity.Func{
+		{File: "01/src/main.go", Name: "main", Cyclomatic: 40, Nesting: 2, Lines: 300},
+		{File: "01/src/lib/lib.go", Name: "main", Cyclomatic: 20, Nesting: 6, Lines: 10},
+		{File: "01/src/lib/lib.go", Name: "small", Cyclomatic: 3, Nesting: 1, Lines: 10},
+	}}
+	violations, err := rep.Check(complexity.Thresholds{Cyclomatic: 15, Nesting: 5, Lines: 100})
+	if err != nil || len(violations) != 4 {
+		t.Fatalf("Check without exclusions = %v, %v, want 4 violations", violations, err)
+	}
+	if got, want := violations[0].String(), "01/src/main.go:0: main: cyclomatic 40 exceeds 15"; got != want {
+		t.Errorf("violation = %q, want %q", got, want)
+	}
+
+	violations, err = rep.Check(complexity.Thresholds{Cyclomatic: 15, Nesting: 5, Exclude: []string{"*/src/main.go:main"}})
+	if err != nil || len(violations) != 2 || violations[0].Func.File != "01/src/lib/lib.go" {
+		t.Errorf("Check excluding */src/main.go:main = %v, %v, want the 2 violations of lib.go", violations, err)
+	}
+
+	// No limit at all
+	if violations, err := rep.Check(complexity.Thresholds{}); err != nil || len(violations) != 0 {
+		t.Errorf("Check without thresholds = %v, %v", violations, err)
+	}
+	if _, err := rep.Check(complexity.Thresholds{Exclude: []string{"["}}); !errors.Is(err, path.ErrBadPattern) {
+		t.Errorf("Check with a bad pattern: err = %v, want ErrBadPattern", err)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.work":                  "go 1.23\n\nuse ./a\n",
+		"a/go.mod":                 "module example.com/a\n\ngo 1.23\n",
+		"a/src/main.go":            "package main\n\nfunc main() {\n\tif true {\n\t}\n}\n",
+		"a/src/testdata/skip.go":   "package skip\n\nfunc skipped() {}\n",
+		"a/bin/skip.go":            "package skip\n\nfunc skipped() {}\n",
+		"a/src/lib/lib.go":         "package lib\n\nfunc F() {}\n",
+		"a/src/lib/not-go-code.md": "func G() {}\n",
+	}
+	for name, content := range files {
+		name = filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	work, err := complexity.FindWork(filepath.Join(root, "a", "src", "lib"))
+	if err != nil || work != filepath.Join(root, "go.work") {
+		t.Fatalf("FindWork = %q, %v", work, err)
+	}
+	rep, err := complexity.Analyze(work)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rep.Summarize()
+	if len(rep.Funcs) != 2 {
+		t.Fatalf("Analyze found %+v, want main and F", rep.Funcs)
+	}
+	for _, fn := range rep.Funcs {
+		if fn.Module != "example.com/a" || (fn.File != "a/src/main.go" && fn.File != "a/src/lib/lib.go") {
+			t.Errorf("unexpected function %+v", fn)
+		}
+	}
+	if s := rep.Summaries[0]; s.Funcs != 2 || s.MaxCyclomatic != 2 || s.AvgCyclomatic != 1.5 {
+		t.Errorf("summary = %+v", s)
+	}
+
+	if _, err := complexity.FindWork(string(filepath.Separator)); !errors.Is(err, complexity.ErrNoWorkspace) {
+		t.Errorf("FindWork(/): err = %v, want ErrNoWorkspace", err)
+	}
+}
@@ -0,0 +1,159 @@
+package complexity
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"text/tabwriter"
+	"time"
+)
+
+// The limits of the measures. Zero means no limit.
+type Thresholds struct {
+	Cyclomatic int `json:"cyclomatic,omitempty"`
+	Nesting    int `json:"nesting,omitempty"`
+	Lines      int `json:"lines,omitempty"`
+	// Functions not checked, as path.Match patterns of file:name,
+	// like */src/main.go:main. They are still measured.
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// Report whether a function matches one of the Exclude patterns.
+// Returns path.ErrBadPattern for a malformed pattern.
+func (t Thresholds) Excludes(fn Func) (bool, error) {
+	for _, pat := range t.Exclude {
+		ok, err := path.Match(pat, fn.File+":"+fn.Name)
+		if err != nil {
+			return false, fmt.Errorf("complexity: exclude pattern %q: %w", pat, err)
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// A function over one of the thresholds.
+type Violation struct {
+	Func Func `json:"func"`
+	// cyclomatic, nesting, or lines
+	Measure string `json:"measure"`
+	Value   int    `json:"value"`
+	Limit   int    `json:"limit"`
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s:%d: %s: %s %d exceeds %d", v.Func.File, v.Func.Line, v.Func.Name, v.Measure, v.Value, v.Limit)
+}
+
+// The totals of a module, to track trends over time.
+type Summary struct {
+	Module        string  `json:"module"`
+	Funcs         int     `json:"funcs"`
+	Lines         int     `json:"lines"`
+	MaxCyclomatic int     `json:"max_cyclomatic"`
+	AvgCyclomatic float64 `json:"avg_cyclomatic"`
+	MaxNesting    int     `json:"max_nesting"`
+}
+
+// The measures of a whole workspace.
+type Report struct {
+	// Path of the go.work file
+	Workspace  string      `json:"workspace"`
+	Date       time.Time   `json:"date"`
+	Modules    []Module    `json:"modules"`
+	Summaries  []Summary   `json:"summaries"`
+	Thresholds Thresholds  `json:"thresholds"`
+	Violations []Violation `json:"violations"`
+	Funcs      []Func      `json:"funcs"`
+}
+
+// Compute the summary of each module, in the order of go.work.
+func (r *Report) Summarize() {
+	r.Summaries = make([]Summary, len(r.Modules))
+	for i, mod := range r.Modules {
+		s := &r.Summaries[i]
+		s.Module = mod.Path
+		total := 0
+		for _, fn := range r.Funcs {
+			if fn.Module != mod.Path {
+				continue
+			}
+			s.Funcs++
+			s.Lines += fn.Lines
+			s.MaxCyclomatic = max(s.MaxCyclomatic, fn.Cyclomatic)
+			s.MaxNesting = max(s.MaxNesting, fn.Nesting)
+			total += fn.Cyclomatic
+		}
+		if s.Funcs > 0 {
+			s.AvgCyclomatic = float64(total) / float64(s.Funcs)
+		}
+	}
+}
+
+// Record the thresholds and the functions over them. Returns the violations.
+func (r *Report) Check(t Thresholds) ([]Violation, error) {
+	r.Thresholds = t
+	r.Violations = []Violation{}
+	for _, fn := range r.Funcs {
+		excluded, err := t.Excludes(fn)
+		if err != nil {
+			return nil, err
+		}
+		if excluded {
+			continue
+		}
+		for _, m := range []struct {
+			name         string
+			value, limit int
+		}{
+			{"cyclomatic", fn.Cyclomatic, t.Cyclomatic},
+			{"nesting", fn.Nesting, t.Nesting},
+			{"lines", fn.Lines, t.Lines},
+		} {
+			if m.limit > 0 && m.value > m.limit {
+				r.Violations = append(r.Violations, Violation{Func: fn, Measure: m.name, Value: m.value, Limit: m.limit})
+			}
+		}
+	}
+	return r.Violations, nil
+}
+
+// Write the module summaries, then the top most complex functions, as tables.
+// top <= 0 lists every function.
+func (r *Report) WriteTable(w io.Writer, top int) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MODULE\tFUNCS\tLINES\tMAX CYCLO\tAVG CYCLO\tMAX NEST")
+	for _, s := range r.Summaries {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%.1f\t%d\n", s.Module, s.Funcs, s.Lines, s.MaxCyclomatic, s.AvgCyclomatic, s.MaxNesting)
+	}
+	fmt.Fprintln(tw)
+
+	// Most complex first
+	funcs := slices.Clone(r.Funcs)
+	slices.SortStableFunc(funcs, func(a, b Func) int {
+		return cmp.Or(
+			cmp.Compare(b.Cyclomatic, a.Cyclomatic),
+			cmp.Compare(b.Nesting, a.Nesting),
+			cmp.Compare(b.Lines, a.Lines),
+		)
+	})
+	if top > 0 && top < len(funcs) {
+		funcs = funcs[:top]
+	}
+	fmt.Fprintln(tw, "CYCLO\tNEST\tLINES\tFUNC\tLOCATION")
+	for _, fn := range funcs {
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%s\t%s:%d\n", fn.Cyclomatic, fn.Nesting, fn.Lines, fn.Name, fn.File, fn.Line)
+	}
+	return tw.Flush()
+}
+
+// Write the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
@@ -0,0 +1,132 @@
+package complexity
+
+import (
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/modfile"
+)
+
+// Returned when no go.work is found in a directory or its parents.
+var ErrNoWorkspace = errors.New("complexity: no go.work found")
+
+// A module of the workspace.
+type Module struct {
+	// Module path, from go.mod
+	Path string `json:"path"`
+	// Directory, relative to the directory of go.work
+	Dir string `json:"dir"`
+}
+
+// Return the path of the go.work file of dir or of its closest parent.
+func FindWork(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		name := filepath.Join(dir, "go.work")
+		if _, err := os.Stat(name); err == nil {
+			return name, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ErrNoWorkspace
+		}
+		dir = parent
+	}
+}
+
+// Return the modules listed by the use directives of a go.work file.
+func Modules(work string) ([]Module, error) {
+	data, err := os.ReadFile(work)
+	if err != nil {
+		return nil, err
+	}
+	wf, err := modfile.ParseWork(work, data, nil)
+	if err != nil {
+		return nil, err
+	}
+	root := filepath.Dir(work)
+	var mods []Module
+	for _, use := range wf.Use {
+		dir := filepath.Clean(filepath.FromSlash(use.Path))
+		gomod := filepath.Join(root, dir, "go.mod")
+		if filepath.IsAbs(dir) {
+			gomod = filepath.Join(dir, "go.mod")
+		}
+		data, err := os.ReadFile(gomod)
+		if err != nil {
+			return nil, err
+		}
+		path := modfile.ModulePath(data)
+		if path == "" {
+			return nil, fmt.Errorf("complexity: %s has no module directive", gomod)
+		}
+		mods = append(mods, Module{Path: path, Dir: dir})
+	}
+	return mods, nil
+}
+
+// Measure every function of every module of a go.work file.
+// Hidden directories, testdata, vendor, and bin are skipped.
+func Analyze(work string) (*Report, error) {
+	mods, err := Modules(work)
+	if err != nil {
+		return nil, err
+	}
+	root := filepath.Dir(work)
+	rep := &Report{Workspace: work, Date: time.Now().UTC(), Modules: mods}
+	fset := token.NewFileSet()
+	for _, mod := range mods {
+		dir := mod.Dir
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(root, dir)
+		}
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path != dir && skipDir(d.Name()) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(path, ".go") {
+				return nil
+			}
+			file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			for _, fn := range File(fset, file) {
+				fn.Module = mod.Path
+				fn.File = filepath.ToSlash(rel)
+				rep.Funcs = append(rep.Funcs, fn)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rep, nil
+}
+
+// Report whether a directory holds no source of the module.
+func skipDir(name string) bool {
+	return strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") ||
+		name == "testdata" || name == "vendor" || name == "bin"
+}
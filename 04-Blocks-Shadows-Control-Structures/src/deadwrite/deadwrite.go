@@ -0,0 +1,454 @@
+// Package deadwrite defines an analyzer that reports assignments that can never be observed:
+//
+//   - Writes to for-range loop variables, which are copies of the elements:
+//     `for i, v := range s { v = 1000 }` does not modify s.
+//   - Writes to parameters, which are copies of the arguments:
+//     `func(n int) { n = n * 2 }` does not modify the caller's variable.
+//   - Appends to slice parameters: `s = append(s, 10)` is invisible to the caller,
+//     which keeps its own slice header.
+//
+// A write is reported when the variable is not read after it, is not read again
+// by an enclosing loop or by a backward goto, and is neither captured by a closure nor has its address taken.
+// For a range value over a variable or field, a suggested fix assigns to the element instead;
+// otherwise, when removing the assignment is safe, a suggested fix deletes it.
+package deadwrite
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Reports assignments to loop copies and parameters that are never observed.
+var Analyzer = &analysis.Analyzer{
+	Name: "deadwrite",
+	Doc:  "report assignments to range loop variables and parameters that can never be observed",
+	URL:  "https://github.com/maevadevs/Go-Developer-Advanced/tree/main/04-Blocks-Shadows-Control-Structures",
+	Run:  run,
+}
+
+// The kind of variable whose writes are checked.
+type kind int
+
+const (
+	rangeVar kind = iota
+	param
+)
+
+// A variable to check, with the block where it is visible.
+type candidate struct {
+	obj   *types.Var
+	kind  kind
+	scope *ast.BlockStmt
+	// For range variables: the range statement that declares it.
+	rng *ast.RangeStmt
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	var diags []analysis.Diagnostic
+	for _, file := range pass.Files {
+		var cands []candidate
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncDecl:
+				if n.Body != nil {
+					cands = append(cands, params(pass, n.Type, n.Body)...)
+				}
+			case *ast.FuncLit:
+				cands = append(cands, params(pass, n.Type, n.Body)...)
+			case *ast.RangeStmt:
+				if n.Tok != token.DEFINE {
+					return true
+				}
+				for _, e := range []ast.Expr{n.Key, n.Value} {
+					if v := varOf(pass, e); v != nil {
+						cands = append(cands, candidate{obj: v, kind: rangeVar, scope: n.Body, rng: n})
+					}
+				}
+			}
+			return true
+		})
+		for _, c := range cands {
+			diags = append(diags, check(pass, c)...)
+		}
+	}
+	// The keys of a range are checked before its values: sort to report in source order
+	slices.SortFunc(diags, func(a, b analysis.Diagnostic) int { return cmp.Compare(a.Pos, b.Pos) })
+	for _, d := range diags {
+		pass.Report(d)
+	}
+	return nil, nil
+}
+
+// Return the parameters of a function as candidates.
+func params(pass *analysis.Pass, typ *ast.FuncType, body *ast.BlockStmt) []candidate {
+	var res []candidate
+	for _, field := range typ.Params.List {
+		for _, name := range field.Names {
+			if v := varOf(pass, name); v != nil {
+				res = append(res, candidate{obj: v, kind: param, scope: body})
+			}
+		}
+	}
+	return res
+}
+
+// Return the variable declared by an identifier, or nil.
+func varOf(pass *analysis.Pass, e ast.Expr) *types.Var {
+	id, ok := e.(*ast.Ident)
+	if !ok || id.Name == "_" {
+		return nil
+	}
+	v, _ := pass.TypesInfo.Defs[id].(*types.Var)
+	return v
+}
+
+// A range of positions that can run again after a write: an enclosing loop,
+// or the code from a label to a backward goto.
+type span struct {
+	pos, end token.Pos
+}
+
+// A statement that writes the variable, and the loops enclosing it within the scope.
+type write struct {
+	stmt  ast.Stmt
+	loops []span
+}
+
+// A goto statement and the position of its label.
+type jump struct {
+	label token.Pos
+	stmt  *ast.BranchStmt
+}
+
+// The uses of a candidate found by walking its scope.
+type usage struct {
+	pass    *analysis.Pass
+	obj     *types.Var
+	writes  []write
+	reads   []token.Pos
+	jumps   []jump
+	escapes bool
+	// The path from the scope to the current node, to know the enclosing loops and closures
+	stack []ast.Node
+}
+
+// Return a diagnostic for each write of a candidate that is never observed.
+func check(pass *analysis.Pass, c candidate) []analysis.Diagnostic {
+	u := &usage{pass: pass, obj: c.obj}
+	ast.Inspect(c.scope, u.visit)
+	if u.escapes {
+		return nil
+	}
+	var diags []analysis.Diagnostic
+	for _, w := range u.writes {
+		// A goto after the write to a label before it runs the write again, like a loop
+		for _, j := range u.jumps {
+			if j.label < w.stmt.Pos() && j.stmt.Pos() >= w.stmt.End() {
+				w.loops = append(w.loops, span{j.label, j.stmt.End()})
+			}
+		}
+		if observed(w, u.reads) {
+			continue
+		}
+		diags = append(diags, report(pass, c, w.stmt))
+	}
+	return diags
+}
+
+// Record the writes, reads, gotos and escapes of the variable, for ast.Inspect.
+func (u *usage) visit(n ast.Node) bool {
+	if n == nil {
+		u.stack = u.stack[:len(u.stack)-1]
+		return true
+	}
+	u.stack = append(u.stack, n)
+	switch n := n.(type) {
+	case *ast.BranchStmt:
+		if l, ok := u.pass.TypesInfo.Uses[n.Label].(*types.Label); ok && n.Tok == token.GOTO {
+			u.jumps = append(u.jumps, jump{label: l.Pos(), stmt: n})
+		}
+	case *ast.UnaryExpr:
+		if n.Op == token.AND && refersTo(u.pass, n.X, u.obj) {
+			u.escapes = true
+		}
+	case *ast.AssignStmt:
+		for _, lhs := range n.Lhs {
+			if n.Tok != token.DEFINE && refersTo(u.pass, lhs, u.obj) {
+				u.writes = append(u.writes, write{n, loopsOf(u.stack)})
+			}
+		}
+	case *ast.IncDecStmt:
+		if refersTo(u.pass, n.X, u.obj) {
+			u.writes = append(u.writes, write{n, loopsOf(u.stack)})
+		}
+	case *ast.Ident:
+		u.ident(n)
+	}
+	return true
+}
+
+// Record a use of the variable by an identifier on top of the stack.
+func (u *usage) ident(id *ast.Ident) {
+	if u.pass.TypesInfo.Uses[id] != u.obj {
+		return
+	}
+	if inClosure(u.stack) {
+		u.escapes = true
+	}
+	if !isPlainAssignTarget(u.stack) {
+		u.reads = append(u.reads, id.Pos())
+	}
+}
+
+// Report whether ident refers to the variable.
+func refersTo(pass *analysis.Pass, e ast.Expr, v *types.Var) bool {
+	id, ok := ast.Unparen(e).(*ast.Ident)
+	return ok && pass.TypesInfo.Uses[id] == v
+}
+
+// Report whether the expression is the identifier that declares the variable.
+func refersToDef(pass *analysis.Pass, e ast.Expr, v *types.Var) bool {
+	id, ok := e.(*ast.Ident)
+	return ok && pass.TypesInfo.Defs[id] == v
+}
+
+// Return the loops in the stack, outermost first. The scope itself is not included.
+func loopsOf(stack []ast.Node) []span {
+	var loops []span
+	for _, n := range stack[1:] {
+		switch n.(type) {
+		case *ast.ForStmt, *ast.RangeStmt:
+			loops = append(loops, span{n.Pos(), n.End()})
+		}
+	}
+	return loops
+}
+
+// Report whether the top of the stack is inside a function literal.
+func inClosure(stack []ast.Node) bool {
+	for _, n := range stack[1:] {
+		if _, ok := n.(*ast.FuncLit); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Report whether the identifier on top of the stack is the target of a plain `=`,
+// which writes without reading.
+func isPlainAssignTarget(stack []ast.Node) bool {
+	id := stack[len(stack)-1]
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch n := stack[i].(type) {
+		case *ast.ParenExpr:
+			id = n
+			continue
+		case *ast.AssignStmt:
+			if n.Tok != token.ASSIGN {
+				return false
+			}
+			for _, lhs := range n.Lhs {
+				if lhs == id {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// Report whether a read may observe the write: a read after it,
+// or any read in a loop or a goto span around it, which runs again after the write.
+func observed(w write, reads []token.Pos) bool {
+	for _, r := range reads {
+		if r >= w.stmt.End() {
+			return true
+		}
+		for _, loop := range w.loops {
+			if r >= loop.pos && r < loop.end {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Describe a dead write with a message adapted to the kind of variable, and one fix:
+// assigning to the element for a range value when possible, else removing the statement when it is safe.
+func report(pass *analysis.Pass, c candidate, stmt ast.Stmt) analysis.Diagnostic {
+	name := c.obj.Name()
+	var msg string
+	var fixes []analysis.SuggestedFix
+	switch {
+	case c.kind == rangeVar && c.rng.Key != nil && refersToDef(pass, c.rng.Key, c.obj):
+		msg = fmt.Sprintf("assignment to range variable %s has no effect: it is a copy of the index or key", name)
+	case c.kind == rangeVar:
+		msg = fmt.Sprintf("assignment to range variable %s has no effect: it is a copy of the element", name)
+		if fix, ok := elementFix(pass, c, stmt); ok {
+			fixes = append(fixes, fix)
+		}
+	case isAppendToSelf(pass, stmt, c.obj):
+		msg = fmt.Sprintf("append to slice parameter %s is never observed by the caller: return the slice or pass a pointer", name)
+	default:
+		msg = fmt.Sprintf("assignment to parameter %s has no effect: it is a copy of the argument", name)
+	}
+	if fixes == nil && removable(pass, stmt) {
+		fixes = append(fixes, analysis.SuggestedFix{
+			Message:   "Remove the assignment",
+			TextEdits: []analysis.TextEdit{removal(pass, stmt)},
+		})
+	}
+	return analysis.Diagnostic{
+		Pos:            stmt.Pos(),
+		End:            stmt.End(),
+		Message:        msg,
+		SuggestedFixes: fixes,
+	}
+}
+
+// Return the edit that deletes stmt. When the statement is alone on its line, the whole line goes,
+// indentation included; when only a comment follows it, the comment stays at the indentation.
+func removal(pass *analysis.Pass, stmt ast.Stmt) analysis.TextEdit {
+	edit := analysis.TextEdit{Pos: stmt.Pos(), End: stmt.End()}
+	tf := pass.Fset.File(stmt.Pos())
+	if pass.ReadFile == nil {
+		return edit
+	}
+	src, err := pass.ReadFile(tf.Name())
+	if err != nil || tf.Size() != len(src) {
+		return edit
+	}
+	start, end := tf.Offset(stmt.Pos()), tf.Offset(stmt.End())
+	lineStart := tf.Offset(tf.LineStart(tf.Line(stmt.Pos())))
+	if len(bytes.TrimLeft(src[lineStart:start], " \t")) != 0 {
+		return edit
+	}
+	rest, _, found := bytes.Cut(src[end:], []byte("\n"))
+	trailing := bytes.TrimLeft(rest, " \t")
+	switch {
+	case len(trailing) == 0:
+		edit.Pos = tf.LineStart(tf.Line(stmt.Pos()))
+		edit.End = stmt.End() + token.Pos(len(rest))
+		if found {
+			edit.End++
+		}
+	case bytes.HasPrefix(trailing, []byte("//")):
+		edit.End = stmt.End() + token.Pos(len(rest)-len(trailing))
+	}
+	return edit
+}
+
+// Report whether stmt is `v = append(v, ...)`.
+func isAppendToSelf(pass *analysis.Pass, stmt ast.Stmt, v *types.Var) bool {
+	as, ok := stmt.(*ast.AssignStmt)
+	if !ok || len(as.Lhs) != 1 || len(as.Rhs) != 1 || !refersTo(pass, as.Lhs[0], v) {
+		return false
+	}
+	call, ok := ast.Unparen(as.Rhs[0]).(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return false
+	}
+	fn, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	_, isBuiltin := pass.TypesInfo.Uses[fn].(*types.Builtin)
+	return isBuiltin && fn.Name == "append" && refersTo(pass, call.Args[0], v)
+}
+
+// Report whether deleting stmt cannot change the behavior of the program:
+// it writes a single variable and evaluates no function calls other than append.
+func removable(pass *analysis.Pass, stmt ast.Stmt) bool {
+	switch s := stmt.(type) {
+	case *ast.IncDecStmt:
+		return true
+	case *ast.AssignStmt:
+		if len(s.Lhs) != 1 {
+			return false
+		}
+		pure := true
+		for _, rhs := range s.Rhs {
+			ast.Inspect(rhs, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				// Conversions and append have no side effects worth keeping
+				if tv, ok := pass.TypesInfo.Types[call.Fun]; ok && tv.IsType() {
+					return true
+				}
+				if fn, ok := ast.Unparen(call.Fun).(*ast.Ident); ok && fn.Name == "append" {
+					if _, ok := pass.TypesInfo.Uses[fn].(*types.Builtin); ok {
+						return true
+					}
+				}
+				pure = false
+				return false
+			})
+		}
+		return pure
+	}
+	return false
+}
+
+// For `for i, v := range s { v = x }`, suggest `s[i] = x` to modify the element.
+// Only offered when the range expression is a slice, array or map that can be indexed by the key,
+// and is a variable or a field, which names the same value again without evaluating anything.
+func elementFix(pass *analysis.Pass, c candidate, stmt ast.Stmt) (analysis.SuggestedFix, bool) {
+	as, ok := stmt.(*ast.AssignStmt)
+	if !ok || as.Tok != token.ASSIGN || len(as.Lhs) != 1 || c.rng.Value == nil {
+		return analysis.SuggestedFix{}, false
+	}
+	if !refersToDef(pass, c.rng.Value, c.obj) {
+		return analysis.SuggestedFix{}, false
+	}
+	key, ok := c.rng.Key.(*ast.Ident)
+	if !ok || key.Name == "_" {
+		return analysis.SuggestedFix{}, false
+	}
+	switch pass.TypesInfo.TypeOf(c.rng.X).Underlying().(type) {
+	case *types.Slice, *types.Array, *types.Map:
+	default:
+		return analysis.SuggestedFix{}, false
+	}
+	if !isVarChain(pass, c.rng.X) {
+		return analysis.SuggestedFix{}, false
+	}
+	var buf bytes.Buffer
+	if err := format.Node(&buf, pass.Fset, c.rng.X); err != nil {
+		return analysis.SuggestedFix{}, false
+	}
+	fmt.Fprintf(&buf, "[%s]", key.Name)
+	return analysis.SuggestedFix{
+		Message:   fmt.Sprintf("Assign to the element %s instead", buf.String()),
+		TextEdits: []analysis.TextEdit{{Pos: as.Lhs[0].Pos(), End: as.Lhs[0].End(), NewText: buf.Bytes()}},
+	}, true
+}
+
+// Report whether e is a variable or a chain of field selections from one, like `x` or `x.f.g`,
+// which is addressable and can be written to.
+func isVarChain(pass *analysis.Pass, e ast.Expr) bool {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.Ident:
+		_, ok := pass.TypesInfo.Uses[e].(*types.Var)
+		return ok
+	case *ast.SelectorExpr:
+		sel, ok := pass.TypesInfo.Selections[e]
+		if !ok {
+			// A qualified identifier: pkg.V
+			_, ok := pass.TypesInfo.Uses[e.Sel].(*types.Var)
+			return ok
+		}
+		return sel.Kind() == types.FieldVal && isVarChain(pass, e.X)
+	}
+	return false
+}
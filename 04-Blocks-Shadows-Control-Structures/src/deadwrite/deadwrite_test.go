@@ -0,0 +1,62 @@
+package deadwrite_test
+
+import (
+	"bytes"
+	"go/format"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/maevadevs/Go-Learning/Blocks-Shadows-Control-Structures/src/deadwrite"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), deadwrite.Analyzer, "params", "ranges")
+}
+
+func TestSourceOrder(t *testing.T) {
+	for _, res := range analysistest.Run(t, analysistest.TestData(), deadwrite.Analyzer, "ranges", "params") {
+		if !slices.IsSortedFunc(res.Diagnostics, func(a, b analysis.Diagnostic) int { return int(a.Pos - b.Pos) }) {
+			t.Errorf("diagnostics of %s out of source order", res.Pass.Pkg.Path())
+		}
+	}
+}
+
+// A Testing that ignores the errors: the lines package has no want comments.
+type quiet struct{}
+
+func (quiet) Errorf(string, ...any) {}
+
+func TestRemoveLine(t *testing.T) {
+	results := analysistest.Run(quiet{}, analysistest.TestData(), deadwrite.Analyzer, "lines")
+	if len(results) != 1 || len(results[0].Diagnostics) != 3 {
+		t.Fatalf("got %d results, want one with 3 diagnostics", len(results))
+	}
+	dir := filepath.Join(analysistest.TestData(), "src", "lines")
+	src, err := os.ReadFile(filepath.Join(dir, "lines.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile(filepath.Join(dir, "lines.go.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Apply the edits from the end, so that the offsets before them stay valid
+	fset := results[0].Pass.Fset
+	got := slices.Clone(src)
+	for _, d := range slices.Backward(results[0].Diagnostics) {
+		for _, e := range d.SuggestedFixes[0].TextEdits {
+			start, end := fset.Position(e.Pos).Offset, fset.Position(e.End).Offset
+			got = slices.Concat(got[:start], e.NewText, got[end:])
+		}
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("after the fixes:\n%s\nwant:\n%s", got, want)
+	}
+	if formatted, err := format.Source(got); err != nil || !bytes.Equal(formatted, got) {
+		t.Errorf("the fixed file is not gofmt-clean: %v", err)
+	}
+}
@@ -0,0 +1,21 @@
+package lines
+
+import "fmt"
+
+// No want comment here: a comment after a statement stays when the statement is removed
+func alone(n int) {
+	fmt.Println(n)
+	n = 2
+}
+
+func inLoop(s []int) {
+	for i, v := range s {
+		fmt.Println(i, v)
+		i++
+		fmt.Println("next")
+	}
+}
+
+func last(n int) {
+	n--
+}
@@ -0,0 +1,62 @@
+package params
+
+import "fmt"
+
+func double(n int) {
+	n = n * 2 // want `assignment to parameter n has no effect: it is a copy of the argument`
+}
+
+func grow(s []int) {
+	s = append(s, 10) // want `append to slice parameter s is never observed by the caller: return the slice or pass a pointer`
+}
+
+func returned(n int) int {
+	n = n * 2
+	return n
+}
+
+// The goto runs n-- again after reading n: the write is observed
+func countdown(n int) {
+top:
+	if n > 0 {
+		fmt.Println(n)
+		n--
+		goto top
+	}
+}
+
+// A forward goto never runs the write again
+func forward(n int) {
+	n++ // want `assignment to parameter n has no effect: it is a copy of the argument`
+	goto end
+end:
+	fmt.Println("done")
+}
+
+// The loop reads n again after the write
+func loop(n int) {
+	for i := 0; i < 3; i++ {
+		fmt.Println(n)
+		n = i
+	}
+}
+
+func captured(n int) func() int {
+	n = 5
+	return func() int { return n }
+}
+
+func addressed(n int) *int {
+	p := &n
+	n = 7
+	return p
+}
+
+func next() int {
+	return 1
+}
+
+// Removing the assignment would remove the call: no fix
+func impure(n int) {
+	n = next() // want `assignment to parameter n has no effect: it is a copy of the argument`
+}
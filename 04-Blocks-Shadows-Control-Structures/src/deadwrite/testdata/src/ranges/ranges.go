@@ -0,0 +1,78 @@
+package ranges
+
+import "fmt"
+
+func elements(s []int) {
+	for i, v := range s {
+		fmt.Println(i, v)
+		v = 1000 // want `assignment to range variable v has no effect: it is a copy of the element`
+	}
+}
+
+type table struct {
+	vals [3]int
+}
+
+func field(t *table) {
+	for i, v := range t.vals {
+		fmt.Println(i, v)
+		v = 0 // want `assignment to range variable v has no effect: it is a copy of the element`
+	}
+}
+
+func load() [3]int { return [3]int{1, 2, 3} }
+
+// s[i] would call load again, and its result cannot be assigned to: remove the write instead
+func called() {
+	for i, v := range load() {
+		fmt.Println(i, v)
+		v = 0 // want `assignment to range variable v has no effect: it is a copy of the element`
+	}
+}
+
+func keys(m map[string]int) {
+	for k := range m {
+		fmt.Println(k)
+		k = "x" // want `assignment to range variable k has no effect: it is a copy of the index or key`
+	}
+}
+
+// Every iteration has a new v: the read before the write does not observe it
+func increment(s []int) {
+	for _, v := range s {
+		fmt.Println(v)
+		v++ // want `assignment to range variable v has no effect: it is a copy of the element`
+	}
+}
+
+func read(s []int) {
+	for _, v := range s {
+		v *= 2
+		fmt.Println(v)
+	}
+}
+
+func nested(s []int) {
+	for _, v := range s {
+		for j := 0; j < 2; j++ {
+			fmt.Println(v)
+			v = j
+		}
+	}
+}
+
+func captured(s []int) {
+	for _, v := range s {
+		defer func() { fmt.Println(v) }()
+		v = 0
+	}
+}
+
+// The key is checked before the value, but the reports are in source order
+func both(m map[string]int) {
+	for k, v := range m {
+		fmt.Println(k, v)
+		v = 1   // want `assignment to range variable v has no effect: it is a copy of the element`
+		k = "x" // want `assignment to range variable k has no effect: it is a copy of the index or key`
+	}
+}
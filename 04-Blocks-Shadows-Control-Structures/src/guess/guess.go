@@ -0,0 +1,104 @@
+// Package guess implements a higher/lower number-guessing game.
+//
+// The game reads guesses from an io.Reader, writes hints to an io.Writer,
+// and draws the secret number from an injected *rand.Rand,
+// so that a whole session can be replayed with a fixed seed and scripted input.
+package guess
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// The range of the secret number and the number of attempts allowed.
+type Difficulty struct {
+	Name     string
+	Max      int
+	Attempts int
+	// Multiplies the score.
+	Bonus int
+}
+
+// The available difficulty levels, from easiest to hardest.
+var Difficulties = []Difficulty{
+	{Name: "easy", Max: 10, Attempts: 5, Bonus: 1},
+	{Name: "normal", Max: 100, Attempts: 7, Bonus: 2},
+	{Name: "hard", Max: 1000, Attempts: 10, Bonus: 5},
+}
+
+// Return the difficulty level with the given name.
+func ParseDifficulty(name string) (Difficulty, error) {
+	for _, d := range Difficulties {
+		if d.Name == name {
+			return d, nil
+		}
+	}
+	return Difficulty{}, fmt.Errorf("unknown difficulty %q", name)
+}
+
+// Returned by Play when the player types "quit".
+var ErrQuit = errors.New("player quit")
+
+// A single game session.
+type Game struct {
+	Difficulty Difficulty
+	// Source of the secret number. Use rand.New(rand.NewPCG(seed, seed)) for reproducible games.
+	Rand *rand.Rand
+	In   io.Reader
+	Out  io.Writer
+}
+
+// The outcome of a game.
+type Result struct {
+	Won      bool
+	Secret   int
+	Attempts int
+	Points   int
+}
+
+// Play one game until the player finds the number, runs out of attempts, or quits.
+// Input that is not a number in range does not use an attempt.
+func (g *Game) Play() (Result, error) {
+	d := g.Difficulty
+	res := Result{Secret: g.Rand.IntN(d.Max) + 1}
+	sc := bufio.NewScanner(g.In)
+
+	fmt.Fprintf(g.Out, "Guess a number between 1 and %d. You have %d attempts.\n", d.Max, d.Attempts)
+	for res.Attempts < d.Attempts {
+		fmt.Fprintf(g.Out, "Attempt %d/%d: ", res.Attempts+1, d.Attempts)
+		if !sc.Scan() {
+			if err := sc.Err(); err != nil {
+				return res, err
+			}
+			return res, io.ErrUnexpectedEOF
+		}
+		input := strings.TrimSpace(sc.Text())
+		if input == "quit" {
+			return res, ErrQuit
+		}
+		n, err := strconv.Atoi(input)
+		if err != nil || n < 1 || n > d.Max {
+			fmt.Fprintf(g.Out, "%q is not a number between 1 and %d\n", input, d.Max)
+			continue
+		}
+		res.Attempts++
+		switch {
+		case n < res.Secret:
+			fmt.Fprintln(g.Out, n, ": That is too low!")
+		case n > res.Secret:
+			fmt.Fprintln(g.Out, n, ": That is too big!")
+		default:
+			res.Won = true
+			res.Points = (d.Attempts - res.Attempts + 1) * d.Bonus
+			fmt.Fprintf(g.Out, "%d : That is the number! Found in %d attempts, %d points.\n", n, res.Attempts, res.Points)
+			return res, nil
+		}
+	}
+	fmt.Fprintln(g.Out, "No more attempts. The number was", res.Secret)
+	return res, nil
+}
@@ -0,0 +1,199 @@
+package guess_test
+
+import (
+	"errors"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maevadevs/Go-Learning/Blocks-Shadows-Control-Structures/src/guess"
+)
+
+// Play a game with a fixed seed and scripted input, and return its result and transcript.
+func play(t *testing.T, difficulty string, seed uint64, input string) (guess.Result, string, error) {
+	t.Helper()
+	d, err := guess.ParseDifficulty(difficulty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out strings.Builder
+	g := guess.Game{
+		Difficulty: d,
+		Rand:       rand.New(rand.NewPCG(seed, seed)),
+		In:         strings.NewReader(input),
+		Out:        &out,
+	}
+	res, err := g.Play()
+	return res, out.String(), err
+}
+
+func TestPlay(t *testing.T) {
+	tests := []struct {
+		name       string
+		difficulty string
+		seed       uint64
+		input      string
+		want       guess.Result
+		wantErr    error
+		transcript string
+	}{
+		{
+			name:       "win",
+			difficulty: "normal",
+			seed:       42,
+			input:      "50\nabc\n75\n 62 \n",
+			want:       guess.Result{Won: true, Secret: 62, Attempts: 3, Points: 10},
+			transcript: "Guess a number between 1 and 100. You have 7 attempts.\n" +
+				"Attempt 1/7: 50 : That is too low!\n" +
+				"Attempt 2/7: \"abc\" is not a number between 1 and 100\n" +
+				"Attempt 2/7: 75 : That is too big!\n" +
+				"Attempt 3/7: 62 : That is the number! Found in 3 attempts, 10 points.\n",
+		},
+		{
+			name:       "lose",
+			difficulty: "easy",
+			seed:       0,
+			input:      "5\n11\n4\n1\n2\n9\n",
+			want:       guess.Result{Secret: 3, Attempts: 5},
+			transcript: "Guess a number between 1 and 10. You have 5 attempts.\n" +
+				"Attempt 1/5: 5 : That is too big!\n" +
+				"Attempt 2/5: \"11\" is not a number between 1 and 10\n" +
+				"Attempt 2/5: 4 : That is too big!\n" +
+				"Attempt 3/5: 1 : That is too low!\n" +
+				"Attempt 4/5: 2 : That is too low!\n" +
+				"Attempt 5/5: 9 : That is too big!\n" +
+				"No more attempts. The number was 3\n",
+		},
+		{
+			name:       "first try",
+			difficulty: "easy",
+			seed:       1,
+			input:      "10\n",
+			want:       guess.Result{Won: true, Secret: 10, Attempts: 1, Points: 5},
+			transcript: "Guess a number between 1 and 10. You have 5 attempts.\n" +
+				"Attempt 1/5: 10 : That is the number! Found in 1 attempts, 5 points.\n",
+		},
+		{
+			name:       "quit",
+			difficulty: "easy",
+			seed:       42,
+			input:      "1\nquit\n",
+			want:       guess.Result{Secret: 7, Attempts: 1},
+			wantErr:    guess.ErrQuit,
+			transcript: "Guess a number between 1 and 10. You have 5 attempts.\n" +
+				"Attempt 1/5: 1 : That is too low!\n" +
+				"Attempt 2/5: ",
+		},
+		{
+			name:       "end of input",
+			difficulty: "easy",
+			seed:       42,
+			input:      "8",
+			want:       guess.Result{Secret: 7, Attempts: 1},
+			wantErr:    io.ErrUnexpectedEOF,
+			transcript: "Guess a number between 1 and 10. You have 5 attempts.\n" +
+				"Attempt 1/5: 8 : That is too big!\n" +
+				"Attempt 2/5: ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, transcript, err := play(t, tt.difficulty, tt.seed, tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if res != tt.want {
+				t.Errorf("result = %+v, want %+v", res, tt.want)
+			}
+			if transcript != tt.transcript {
+				t.Errorf("transcript:\n%s\nwant:\n%s", transcript, tt.transcript)
+			}
+		})
+	}
+}
+
+func TestSaveScore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scores.json")
+	if scores, err := guess.LoadScores(path); scores != nil || err != nil {
+		t.Fatalf("LoadScores of a missing file = %v, %v, want nothing", scores, err)
+	}
+
+	date := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	res, _, err := play(t, "normal", 42, "50\n75\n62\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	win := guess.Score{Name: "Mary", Difficulty: "normal", Points: res.Points, Attempts: res.Attempts, Date: date}
+	rank, err := guess.SaveScore(path, win)
+	if rank != 1 || err != nil {
+		t.Fatalf("SaveScore = %d, %v, want rank 1", rank, err)
+	}
+	// Fewer points rank lower, same points and fewer attempts rank higher
+	low := guess.Score{Name: "Bob", Difficulty: "easy", Points: 2, Attempts: 4, Date: date.Add(time.Hour)}
+	if rank, err = guess.SaveScore(path, low); rank != 2 || err != nil {
+		t.Fatalf("SaveScore = %d, %v, want rank 2", rank, err)
+	}
+	fast := guess.Score{Name: "Ann", Difficulty: "hard", Points: 10, Attempts: 2, Date: date.Add(2 * time.Hour)}
+	if rank, err = guess.SaveScore(path, fast); rank != 1 || err != nil {
+		t.Fatalf("SaveScore = %d, %v, want rank 1", rank, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[
+  {
+    "name": "Ann",
+    "difficulty": "hard",
+    "points": 10,
+    "attempts": 2,
+    "date": "2024-01-02T05:04:05Z"
+  },
+  {
+    "name": "Mary",
+    "difficulty": "normal",
+    "points": 10,
+    "attempts": 3,
+    "date": "2024-01-02T03:04:05Z"
+  },
+  {
+    "name": "Bob",
+    "difficulty": "easy",
+    "points": 2,
+    "attempts": 4,
+    "date": "2024-01-02T04:04:05Z"
+  }
+]`
+	if string(data) != want {
+		t.Errorf("score file:\n%s\nwant:\n%s", data, want)
+	}
+	// The same score at the same instant in another location ties with the saved one
+	again := win
+	again.Date = date.In(time.FixedZone("UTC+2", 2*60*60))
+	if rank, err = guess.SaveScore(path, again); rank != 2 || err != nil {
+		t.Errorf("SaveScore of an equal score = %d, %v, want rank 2", rank, err)
+	}
+}
+
+func TestSaveScoreKeepsBest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scores.json")
+	date := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	for i := range guess.MaxScores {
+		if _, err := guess.SaveScore(path, guess.Score{Name: "p", Points: 10 + i, Attempts: 1, Date: date}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Worse than every kept score: not saved
+	if rank, err := guess.SaveScore(path, guess.Score{Name: "last", Points: 1, Attempts: 1, Date: date}); rank != 0 || err != nil {
+		t.Errorf("SaveScore of a low score = %d, %v, want rank 0", rank, err)
+	}
+	scores, err := guess.LoadScores(path)
+	if err != nil || len(scores) != guess.MaxScores || scores[0].Points != 10+guess.MaxScores-1 {
+		t.Errorf("LoadScores = %d scores, %v, want %d best first", len(scores), err, guess.MaxScores)
+	}
+}
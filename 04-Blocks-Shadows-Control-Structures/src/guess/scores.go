@@ -0,0 +1,78 @@
+package guess
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"slices"
+	"time"
+)
+
+// How many scores the score file keeps.
+const MaxScores = 10
+
+// A finished game, as stored in the score file.
+type Score struct {
+	Name       string    `json:"name"`
+	Difficulty string    `json:"difficulty"`
+	Points     int       `json:"points"`
+	Attempts   int       `json:"attempts"`
+	Date       time.Time `json:"date"`
+}
+
+// Report whether a and b are the same score. The dates are compared with Time.Equal:
+// == would also compare the monotonic clock reading and the location.
+func sameScore(a, b Score) bool {
+	return a.Name == b.Name && a.Difficulty == b.Difficulty && a.Points == b.Points &&
+		a.Attempts == b.Attempts && a.Date.Equal(b.Date)
+}
+
+// Read the scores from a JSON file. A missing file means no scores yet.
+func LoadScores(path string) ([]Score, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var scores []Score
+	if err := json.Unmarshal(data, &scores); err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+// Add a score to the file, keeping only the best MaxScores.
+// Returns the rank of the new score, starting at 1, or 0 if it did not make the list.
+func SaveScore(path string, s Score) (int, error) {
+	scores, err := LoadScores(path)
+	if err != nil {
+		return 0, err
+	}
+	scores = append(scores, s)
+	// Best points first, then fewest attempts, then oldest
+	slices.SortStableFunc(scores, func(a, b Score) int {
+		return cmp.Or(
+			cmp.Compare(b.Points, a.Points),
+			cmp.Compare(a.Attempts, b.Attempts),
+			a.Date.Compare(b.Date),
+		)
+	})
+	scores = scores[:min(len(scores), MaxScores)]
+
+	rank := 0
+	for i, sc := range scores {
+		if sameScore(sc, s) {
+			rank = i + 1
+			break
+		}
+	}
+	data, err := json.MarshalIndent(scores, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	return rank, os.WriteFile(path, data, 0o644)
+}
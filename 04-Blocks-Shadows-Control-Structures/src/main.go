@@ -1,10 +1,19 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"math/rand"
+	randv2 "math/rand/v2"
+	"os"
 	"strings"
+
+	"github.com/maevadevs/Go-Learning/Blocks-Shadows-Control-Structures/src/guess"
+	"github.com/maevadevs/Go-Learning/Blocks-Shadows-Control-Structures/src/omap"
+	"github.com/maevadevs/Go-Learning/Blocks-Shadows-Control-Structures/src/rules"
+	"github.com/maevadevs/Go-Learning/Blocks-Shadows-Control-Structures/src/runeinfo"
+	"github.com/maevadevs/Go-Learning/Blocks-Shadows-Control-Structures/src/wordclass"
 )
 
 // This is the main entry of the application.
@@ -24,6 +33,7 @@ func main() {
 	if x > 5 {
 		fmt.Println("\tInside the block before shadowing, x is:", x)
 		// This variable is shadowing the outside variable
+		//shadow:ignore
 		x := 5
 		fmt.Println("\tInside the block after shadowing, x is:", x)
 	}
@@ -38,6 +48,7 @@ func main() {
 
 	fmt.Println("Outside the block, x =", x)
 	if x > 5 {
+		//shadow:ignore
 		x, y := 5, 20
 		fmt.Println("\tInside the block, x =", x, "and y =", y)
 	}
@@ -53,6 +64,7 @@ func main() {
 
 	fmt.Println("Outside the block, math.Pi =", math.Pi)
 	if float64(x) > pi {
+		//shadow:ignore
 		math := "oops!"
 		// This is an error: math.Pi is undefined because math == "oops!"
 		// pi2 := math.Pi
@@ -77,6 +89,21 @@ func main() {
 	}
 	fmt.Println()
 
+	// Example of a Seeded Guessing Game
+	// ---------------------------------
+	fmt.Println("Example of a Seeded Guessing Game:")
+	fmt.Println("----------------------------------")
+
+	// A fixed seed and scripted input replay the same session every time
+	game := guess.Game{
+		Difficulty: guess.Difficulties[0],
+		Rand:       randv2.New(randv2.NewPCG(42, 42)),
+		In:         strings.NewReader("5\n8\nseven\n7\n"),
+		Out:        os.Stdout,
+	}
+	game.Play()
+	fmt.Println()
+
 	// Example of `if` With Scoped Variables
 	// -------------------------------------
 	fmt.Println("Example of `if` With Scoped Variables:")
@@ -239,6 +266,39 @@ func main() {
 	}
 	fmt.Println()
 
+	// Example of Ordered Maps
+	// -----------------------
+	fmt.Println("Example of Ordered Maps:")
+	fmt.Println("------------------------")
+
+	// Insertion order, whatever the keys
+	om := omap.NewOrdered[string, int]()
+	om.Set("c", 3)
+	om.Set("a", 1)
+	om.Set("b", 2)
+	// Increasing key order, whatever the insertion order
+	sm := omap.NewSorted[string, int]()
+	for k, v := range om.All() {
+		sm.Set(k, v)
+	}
+	for i := 0; i < 3; i++ {
+		fmt.Printf("Loop iteration %d: ", i)
+		for k, v := range om.All() {
+			fmt.Printf("%s:%d ", k, v)
+		}
+		fmt.Print("| ")
+		for k, v := range sm.All() {
+			fmt.Printf("%s:%d ", k, v)
+		}
+		fmt.Println()
+	}
+	// JSON keeps the same order
+	omJSON, _ := json.Marshal(om)
+	smJSON, _ := json.Marshal(sm)
+	fmt.Println("OrderedMap as JSON:", string(omJSON))
+	fmt.Println("SortedMap as JSON:", string(smJSON))
+	fmt.Println()
+
 	// Using for-range With Strings
 	// ----------------------------
 	fmt.Println("Using for-range With Strings:")
@@ -256,6 +316,17 @@ func main() {
 		fmt.Println()
 	}
 
+	// Example of Inspecting Runes and Grapheme Clusters
+	// -------------------------------------------------
+	fmt.Println("Example of Inspecting Runes and Grapheme Clusters:")
+	fmt.Println("--------------------------------------------------")
+
+	// 4 user-perceived characters, but 6 runes and 13 bytes
+	if err := runeinfo.Inspect("e\u0301 👍🏽!").WriteTable(os.Stdout); err != nil {
+		fmt.Println("Error:", err)
+	}
+	fmt.Println()
+
 	// Modifying for-range Loop Variables: No effect on Compound
 	// ---------------------------------------------------------
 	fmt.Println("Modifying for-range Loop Variables: No effect on Compound:")
@@ -321,6 +392,22 @@ outerLoop:
 	}
 	fmt.Println()
 
+	// Example of Configurable Word Classes
+	// ------------------------------------
+	fmt.Println("Example of Configurable Word Classes:")
+	fmt.Println("-------------------------------------")
+
+	// The same thresholds as data, counting runes instead of bytes
+	classifier, err := wordclass.New(wordclass.DefaultConfig.Dimensions...)
+	if err != nil {
+		fmt.Println("Error:", err)
+	} else {
+		for _, word := range append(words, "π-day", "naïve") {
+			fmt.Println("-", word, "is", classifier.Classify(word)[0])
+		}
+	}
+	fmt.Println()
+
 	// Using switch Within for-loop
 	// ----------------------------
 	fmt.Println("Using switch Within for-loop:")
@@ -393,6 +480,29 @@ switchLoop:
 		}
 	}
 	fmt.Println()
+	fmt.Println()
+
+	// Fizzbuzz: With a Rule Engine
+	// ----------------------------
+	fmt.Println("Fizzbuzz: With a Rule Engine")
+	fmt.Println("----------------------------")
+
+	// The rules are loaded from a config file instead of being hard-coded
+	engine, err := rules.LoadFile("src/textfiles/fizzbuzz.json")
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		engine.Write(os.Stdout, rules.Range(1, 25))
+		fmt.Println()
+	}
+
+	// Changing the game only requires different rules
+	engine, _ = rules.New(
+		rules.Rule{Predicate: "prime", Word: "Prime", Stop: true},
+		rules.Rule{Divisor: 2, Word: "Even", Priority: 1},
+	)
+	engine.Write(os.Stdout, rules.Range(1, 11))
+	fmt.Println()
 	// Footers
 	fmt.Println()
 	fmt.Println(strings.Repeat("-", 100))
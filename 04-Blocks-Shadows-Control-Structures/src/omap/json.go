@@ -0,0 +1,121 @@
+package omap
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"reflect"
+	"strconv"
+)
+
+// Encode the entries of a sequence as a JSON object, in sequence order.
+func marshalObject[K, V any](seq iter.Seq2[K, V]) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	first := true
+	for k, v := range seq {
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		ks, err := keyString(k)
+		if err != nil {
+			return nil, err
+		}
+		kb, _ := json.Marshal(ks)
+		buf.Write(kb)
+		buf.WriteByte(':')
+		vb, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(vb)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Decode a JSON object and call set for each entry, in the order of the document.
+// Like for built-in maps, null leaves the map unchanged.
+func unmarshalObject[K, V any](data []byte, set func(K, V)) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("omap: expected a JSON object, got %v", tok)
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var key K
+		if err := parseKey(tok.(string), &key); err != nil {
+			return err
+		}
+		var val V
+		if err := dec.Decode(&val); err != nil {
+			return err
+		}
+		set(key, val)
+	}
+	// The closing brace
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert a key to the string used as a JSON object key,
+// following the same rules as encoding/json for map keys.
+func keyString(key any) (string, error) {
+	if tm, ok := key.(encoding.TextMarshaler); ok {
+		b, err := tm.MarshalText()
+		return string(b), err
+	}
+	rv := reflect.ValueOf(key)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	default:
+		return "", fmt.Errorf("omap: unsupported JSON key type %T", key)
+	}
+}
+
+// Parse a JSON object key into *key, the reverse of keyString.
+func parseKey(s string, key any) error {
+	if tu, ok := key.(encoding.TextUnmarshaler); ok {
+		return tu.UnmarshalText([]byte(s))
+	}
+	rv := reflect.ValueOf(key).Elem()
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, rv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("omap: key %q: %w", s, err)
+		}
+		rv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, rv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("omap: key %q: %w", s, err)
+		}
+		rv.SetUint(n)
+	default:
+		return fmt.Errorf("omap: unsupported JSON key type %s", rv.Type())
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package omap_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maevadevs/Go-Learning/Blocks-Shadows-Control-Structures/src/omap"
+)
+
+// Create an ordered map with keys a, b, c, ... and values 1, 2, 3, ...
+func abcd(n int) *omap.OrderedMap[string, int] {
+	m := omap.NewOrdered[string, int]()
+	for i := range n {
+		m.Set(string(rune('a'+i)), i+1)
+	}
+	return m
+}
+
+func TestOrderedMap(t *testing.T) {
+	var m omap.OrderedMap[string, int]
+	if m.Len() != 0 || m.Has("a") || slices.Collect(m.Keys()) != nil {
+		t.Fatal("zero value is not an empty map")
+	}
+	m.Set("c", 3)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("a", 10)
+	if got, want := slices.Collect(m.Keys()), []string{"c", "a", "b"}; !slices.Equal(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+	if got, want := slices.Collect(m.Values()), []int{3, 10, 2}; !slices.Equal(got, want) {
+		t.Errorf("Values() = %v, want %v", got, want)
+	}
+	if v, ok := m.Get("a"); v != 10 || !ok {
+		t.Errorf("Get(a) = %d, %v, want 10, true", v, ok)
+	}
+	if !m.Delete("c") || m.Delete("c") || m.Has("c") || m.Len() != 2 {
+		t.Error("Delete(c) did not remove c exactly once")
+	}
+	// A deleted key comes back at the end
+	m.Set("c", 30)
+	if got, want := m.String(), "map[a:10 b:2 c:30]"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestOrderedDeleteDuringIteration(t *testing.T) {
+	tests := []struct {
+		name string
+		// Called with each visited key
+		visit func(m *omap.OrderedMap[string, int], k string)
+		want  []string
+	}{
+		{"current", func(m *omap.OrderedMap[string, int], k string) { m.Delete(k) }, []string{"a", "b", "c", "d"}},
+		{"next", func(m *omap.OrderedMap[string, int], k string) {
+			if k == "a" {
+				m.Delete("b")
+			}
+		}, []string{"a", "c", "d"}},
+		{"current and next", func(m *omap.OrderedMap[string, int], k string) {
+			if k == "b" {
+				m.Delete("b")
+				m.Delete("c")
+			}
+		}, []string{"a", "b", "d"}},
+		{"previous then current", func(m *omap.OrderedMap[string, int], k string) {
+			if k == "c" {
+				m.Delete("b")
+				m.Delete("c")
+			}
+		}, []string{"a", "b", "c", "d"}},
+		{"last", func(m *omap.OrderedMap[string, int], k string) {
+			if k == "a" {
+				m.Delete("d")
+			}
+		}, []string{"a", "b", "c"}},
+		{"all", func(m *omap.OrderedMap[string, int], k string) {
+			if k == "a" {
+				for _, k := range []string{"a", "b", "c", "d"} {
+					m.Delete(k)
+				}
+			}
+		}, []string{"a"}},
+		{"current then add", func(m *omap.OrderedMap[string, int], k string) {
+			if k == "d" {
+				m.Delete("d")
+				m.Set("e", 5)
+			}
+		}, []string{"a", "b", "c", "d", "e"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := abcd(4)
+			var got []string
+			for k := range m.All() {
+				got = append(got, k)
+				tt.visit(m, k)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("visited %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderedJSON(t *testing.T) {
+	m := omap.NewOrdered[int, string]()
+	m.Set(3, "c")
+	m.Set(1, "a")
+	m.Set(2, "b")
+
+	holder := struct {
+		ByValue   omap.OrderedMap[int, string]
+		ByPointer *omap.OrderedMap[int, string]
+	}{*m, m}
+	data, err := json.Marshal(holder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `{"ByValue":{"3":"c","1":"a","2":"b"},"ByPointer":{"3":"c","1":"a","2":"b"}}`; got != want {
+		t.Errorf("json.Marshal = %s, want %s", got, want)
+	}
+
+	var back omap.OrderedMap[int, string]
+	if err := json.Unmarshal([]byte(`{"2":"x","1":"y","2":"z"}`), &back); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := back.String(), "map[2:z 1:y]"; got != want {
+		t.Errorf("decoded %q, want %q", got, want)
+	}
+	if err := json.Unmarshal([]byte("null"), &back); err != nil || back.Len() != 2 {
+		t.Errorf("Unmarshal(null) = %v, len %d, want no-op", err, back.Len())
+	}
+	if err := back.UnmarshalJSON([]byte(" null ")); err != nil {
+		t.Errorf("UnmarshalJSON(null) = %v, want nil", err)
+	}
+	if err := json.Unmarshal([]byte(`[1]`), &back); err == nil {
+		t.Error("Unmarshal of an array: no error")
+	}
+	if err := json.Unmarshal([]byte(`{"x":"a"}`), &back); err == nil {
+		t.Error("Unmarshal of a non-integer key: no error")
+	}
+}
+
+func TestSortedMap(t *testing.T) {
+	var m omap.SortedMap[int, int]
+	// Enough keys to split and merge B-tree nodes
+	const n = 1000
+	for i := range n {
+		m.Set((i*7919)%n, i)
+	}
+	if m.Len() != n {
+		t.Fatalf("Len() = %d, want %d", m.Len(), n)
+	}
+	keys := slices.Collect(m.Keys())
+	if !slices.IsSorted(keys) || len(keys) != n {
+		t.Fatalf("Keys() not sorted or missing keys: %d keys", len(keys))
+	}
+	for i := 0; i < n; i += 2 {
+		if !m.Delete(i) {
+			t.Fatalf("Delete(%d) = false", i)
+		}
+	}
+	if m.Delete(0) || m.Has(0) || m.Len() != n/2 {
+		t.Fatalf("after deleting even keys: Len() = %d", m.Len())
+	}
+	for k := range m.Keys() {
+		if k%2 == 0 {
+			t.Fatalf("even key %d still present", k)
+		}
+	}
+	if v, ok := m.Get(7919 % n); v != 1 || !ok {
+		t.Errorf("Get(%d) = %d, %v, want 1, true", 7919%n, v, ok)
+	}
+}
+
+func TestSortedJSON(t *testing.T) {
+	var m omap.SortedMap[string, int]
+	m.Set("b", 2)
+	m.Set("a", 1)
+	data, err := json.Marshal(struct{ M omap.SortedMap[string, int] }{m})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `{"M":{"a":1,"b":2}}`; got != want {
+		t.Errorf("json.Marshal = %s, want %s", got, want)
+	}
+	if err := json.Unmarshal([]byte("null"), &m); err != nil || m.Len() != 2 {
+		t.Errorf("Unmarshal(null) = %v, len %d, want no-op", err, m.Len())
+	}
+	if got, want := fmt.Sprint(m), "map[a:1 b:2]"; got != want {
+		t.Errorf("fmt.Sprint = %q, want %q", got, want)
+	}
+}
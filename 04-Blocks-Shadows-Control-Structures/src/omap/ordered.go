@@ -0,0 +1,172 @@
+// Package omap provides maps with a predictable iteration order.
+//
+// Ranging over a built-in map visits the keys in a different order on every run.
+// OrderedMap visits them in insertion order, and SortedMap in increasing key order.
+// Both support range-over-func iterators and encode to JSON objects in that order.
+package omap
+
+import (
+	"bytes"
+	"fmt"
+	"iter"
+)
+
+// An entry of the insertion-order list.
+type entry[K comparable, V any] struct {
+	key        K
+	val        V
+	prev, next *entry[K, V]
+}
+
+// A map that remembers the order in which keys were first inserted.
+// Get, Set and Delete are O(1). The zero value is ready to use.
+type OrderedMap[K comparable, V any] struct {
+	index map[K]*entry[K, V]
+	// Sentinel of a circular doubly-linked list: root.next is the oldest entry.
+	// A pointer, so that a copy of the map still ends its walk on the same sentinel.
+	root *entry[K, V]
+}
+
+// Create an empty ordered map.
+func NewOrdered[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{}
+}
+
+// Initialize the map on first use.
+func (m *OrderedMap[K, V]) lazyInit() {
+	if m.index == nil {
+		m.index = map[K]*entry[K, V]{}
+		m.root = &entry[K, V]{}
+		m.root.next = m.root
+		m.root.prev = m.root
+	}
+}
+
+// Return the number of entries.
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.index)
+}
+
+// Return the value of a key, and whether it was present.
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	if e, ok := m.index[key]; ok {
+		return e.val, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Report whether a key is present.
+func (m *OrderedMap[K, V]) Has(key K) bool {
+	_, ok := m.index[key]
+	return ok
+}
+
+// Set the value of a key.
+// A new key goes to the end; an existing key keeps its position.
+func (m *OrderedMap[K, V]) Set(key K, val V) {
+	m.lazyInit()
+	if e, ok := m.index[key]; ok {
+		e.val = val
+		return
+	}
+	e := &entry[K, V]{key: key, val: val, prev: m.root.prev, next: m.root}
+	m.root.prev.next = e
+	m.root.prev = e
+	m.index[key] = e
+}
+
+// Remove a key. Reports whether it was present.
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	e, ok := m.index[key]
+	if !ok {
+		return false
+	}
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	// A nil next marks the entry as removed. Its prev is kept for an iterator
+	// standing on it, to find its way back into the list.
+	e.next = nil
+	delete(m.index, key)
+	return true
+}
+
+// Iterate over the keys and values in insertion order.
+// Any entry may be deleted during the iteration, and is not visited if not visited yet.
+// Entries added during the iteration are visited.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.index == nil {
+			return
+		}
+		for e := m.root.next; e != m.root; e = successor(e) {
+			if !yield(e.key, e.val) {
+				return
+			}
+		}
+	}
+}
+
+// Return the entry after e, even if yield deleted e or its neighbors:
+// go back to the nearest entry still in the list, then take its next.
+func successor[K comparable, V any](e *entry[K, V]) *entry[K, V] {
+	for e.next == nil {
+		e = e.prev
+	}
+	return e.next
+}
+
+// Iterate over the keys in insertion order.
+func (m *OrderedMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Iterate over the values in insertion order.
+func (m *OrderedMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Print the map like a built-in map, but in insertion order: map[a:1 b:2]
+func (m OrderedMap[K, V]) String() string {
+	return formatMap(m.All())
+}
+
+// Encode the map as a JSON object with the keys in insertion order.
+// A value receiver, so that a map held by value encodes too.
+func (m OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	return marshalObject(m.All())
+}
+
+// Decode a JSON object, inserting the keys in the order they appear.
+// Existing entries are kept; duplicate keys keep their first position. null is a no-op.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, m.Set)
+}
+
+// Print the entries of a sequence like a built-in map.
+func formatMap[K, V any](seq iter.Seq2[K, V]) string {
+	var buf bytes.Buffer
+	buf.WriteString("map[")
+	first := true
+	for k, v := range seq {
+		if !first {
+			buf.WriteByte(' ')
+		}
+		first = false
+		fmt.Fprintf(&buf, "%v:%v", k, v)
+	}
+	buf.WriteByte(']')
+	return buf.String()
+}
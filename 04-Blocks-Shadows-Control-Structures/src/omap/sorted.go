@@ -0,0 +1,304 @@
+package omap
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// Minimum degree of the B-tree: every node but the root holds between
+// degree-1 and 2*degree-1 keys.
+const degree = 16
+
+// A node of the B-tree. Leaves have no children.
+type node[K cmp.Ordered, V any] struct {
+	keys     []K
+	vals     []V
+	children []*node[K, V]
+}
+
+// Report whether the node is a leaf.
+func (n *node[K, V]) leaf() bool {
+	return len(n.children) == 0
+}
+
+// A map that iterates in increasing key order, backed by a B-tree.
+// Get, Set and Delete are O(log n). The zero value is ready to use.
+type SortedMap[K cmp.Ordered, V any] struct {
+	root *node[K, V]
+	size int
+}
+
+// Create an empty sorted map.
+func NewSorted[K cmp.Ordered, V any]() *SortedMap[K, V] {
+	return &SortedMap[K, V]{}
+}
+
+// Return the number of entries.
+func (m *SortedMap[K, V]) Len() int {
+	return m.size
+}
+
+// Return the value of a key, and whether it was present.
+func (m *SortedMap[K, V]) Get(key K) (V, bool) {
+	for n := m.root; n != nil; {
+		i, found := slices.BinarySearch(n.keys, key)
+		if found {
+			return n.vals[i], true
+		}
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+	var zero V
+	return zero, false
+}
+
+// Report whether a key is present.
+func (m *SortedMap[K, V]) Has(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Set the value of a key.
+func (m *SortedMap[K, V]) Set(key K, val V) {
+	if m.root == nil {
+		m.root = &node[K, V]{}
+	}
+	// Split a full root first, so that insertion never has to go back up
+	if len(m.root.keys) == 2*degree-1 {
+		old := m.root
+		m.root = &node[K, V]{children: []*node[K, V]{old}}
+		m.root.splitChild(0)
+	}
+	if m.root.insert(key, val) {
+		m.size++
+	}
+}
+
+// Split the full child i into two nodes, moving its middle key up into n.
+func (n *node[K, V]) splitChild(i int) {
+	child := n.children[i]
+	mid := degree - 1
+	right := &node[K, V]{
+		keys: slices.Clone(child.keys[mid+1:]),
+		vals: slices.Clone(child.vals[mid+1:]),
+	}
+	if !child.leaf() {
+		right.children = slices.Clone(child.children[mid+1:])
+		child.children = child.children[:mid+1]
+	}
+	n.keys = slices.Insert(n.keys, i, child.keys[mid])
+	n.vals = slices.Insert(n.vals, i, child.vals[mid])
+	n.children = slices.Insert(n.children, i+1, right)
+	child.keys = child.keys[:mid]
+	child.vals = child.vals[:mid]
+}
+
+// Insert into a node that is not full. Reports whether the key is new.
+func (n *node[K, V]) insert(key K, val V) bool {
+	for {
+		i, found := slices.BinarySearch(n.keys, key)
+		if found {
+			n.vals[i] = val
+			return false
+		}
+		if n.leaf() {
+			n.keys = slices.Insert(n.keys, i, key)
+			n.vals = slices.Insert(n.vals, i, val)
+			return true
+		}
+		if len(n.children[i].keys) == 2*degree-1 {
+			n.splitChild(i)
+			// The middle key moved up to i: decide which half to descend into
+			switch c := cmp.Compare(key, n.keys[i]); {
+			case c == 0:
+				n.vals[i] = val
+				return false
+			case c > 0:
+				i++
+			}
+		}
+		n = n.children[i]
+	}
+}
+
+// Remove a key. Reports whether it was present.
+func (m *SortedMap[K, V]) Delete(key K) bool {
+	if m.root == nil {
+		return false
+	}
+	found := m.root.remove(key)
+	// The root lost its last key: its only child becomes the root
+	if len(m.root.keys) == 0 {
+		if m.root.leaf() {
+			m.root = nil
+		} else {
+			m.root = m.root.children[0]
+		}
+	}
+	if found {
+		m.size--
+	}
+	return found
+}
+
+// Remove a key from the subtree. Every node visited has at least degree keys,
+// except the root, so that a key can always be taken from it.
+func (n *node[K, V]) remove(key K) bool {
+	i, found := slices.BinarySearch(n.keys, key)
+	if n.leaf() {
+		if found {
+			n.keys = slices.Delete(n.keys, i, i+1)
+			n.vals = slices.Delete(n.vals, i, i+1)
+		}
+		return found
+	}
+	if found {
+		switch {
+		case len(n.children[i].keys) >= degree:
+			// Replace with the predecessor, then remove it from the left child
+			pred := n.children[i]
+			for !pred.leaf() {
+				pred = pred.children[len(pred.children)-1]
+			}
+			last := len(pred.keys) - 1
+			n.keys[i], n.vals[i] = pred.keys[last], pred.vals[last]
+			n.children[i].remove(n.keys[i])
+			return true
+		case len(n.children[i+1].keys) >= degree:
+			// Replace with the successor, then remove it from the right child
+			succ := n.children[i+1]
+			for !succ.leaf() {
+				succ = succ.children[0]
+			}
+			n.keys[i], n.vals[i] = succ.keys[0], succ.vals[0]
+			n.children[i+1].remove(n.keys[i])
+			return true
+		default:
+			n.merge(i)
+			return n.children[i].remove(key)
+		}
+	}
+	// Make sure the child to descend into can lose a key
+	if len(n.children[i].keys) < degree {
+		i = n.fill(i)
+	}
+	return n.children[i].remove(key)
+}
+
+// Give child i at least degree keys, by borrowing from a sibling or merging with one.
+// Returns the index of the child that now covers the same keys.
+func (n *node[K, V]) fill(i int) int {
+	switch {
+	case i > 0 && len(n.children[i-1].keys) >= degree:
+		// Borrow the last key of the left sibling through the parent
+		child, left := n.children[i], n.children[i-1]
+		last := len(left.keys) - 1
+		child.keys = slices.Insert(child.keys, 0, n.keys[i-1])
+		child.vals = slices.Insert(child.vals, 0, n.vals[i-1])
+		n.keys[i-1], n.vals[i-1] = left.keys[last], left.vals[last]
+		left.keys, left.vals = left.keys[:last], left.vals[:last]
+		if !left.leaf() {
+			child.children = slices.Insert(child.children, 0, left.children[last+1])
+			left.children = left.children[:last+1]
+		}
+		return i
+	case i < len(n.keys) && len(n.children[i+1].keys) >= degree:
+		// Borrow the first key of the right sibling through the parent
+		child, right := n.children[i], n.children[i+1]
+		child.keys = append(child.keys, n.keys[i])
+		child.vals = append(child.vals, n.vals[i])
+		n.keys[i], n.vals[i] = right.keys[0], right.vals[0]
+		right.keys = slices.Delete(right.keys, 0, 1)
+		right.vals = slices.Delete(right.vals, 0, 1)
+		if !right.leaf() {
+			child.children = append(child.children, right.children[0])
+			right.children = slices.Delete(right.children, 0, 1)
+		}
+		return i
+	case i < len(n.keys):
+		n.merge(i)
+		return i
+	default:
+		n.merge(i - 1)
+		return i - 1
+	}
+}
+
+// Merge child i+1 and the key i into child i.
+func (n *node[K, V]) merge(i int) {
+	child, right := n.children[i], n.children[i+1]
+	child.keys = append(append(child.keys, n.keys[i]), right.keys...)
+	child.vals = append(append(child.vals, n.vals[i]), right.vals...)
+	child.children = append(child.children, right.children...)
+	n.keys = slices.Delete(n.keys, i, i+1)
+	n.vals = slices.Delete(n.vals, i, i+1)
+	n.children = slices.Delete(n.children, i+1, i+2)
+}
+
+// Iterate over the keys and values in increasing key order.
+// The map must not be modified during the iteration.
+func (m *SortedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.root.walk(yield)
+	}
+}
+
+// Visit the subtree in order. Returns false if yield asked to stop.
+func (n *node[K, V]) walk(yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	for i := range n.keys {
+		if !n.leaf() && !n.children[i].walk(yield) {
+			return false
+		}
+		if !yield(n.keys[i], n.vals[i]) {
+			return false
+		}
+	}
+	if !n.leaf() {
+		return n.children[len(n.children)-1].walk(yield)
+	}
+	return true
+}
+
+// Iterate over the keys in increasing order.
+func (m *SortedMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Iterate over the values in increasing key order.
+func (m *SortedMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Print the map like a built-in map: map[a:1 b:2]
+func (m SortedMap[K, V]) String() string {
+	return formatMap(m.All())
+}
+
+// Encode the map as a JSON object with the keys in increasing order.
+// A value receiver, so that a map held by value encodes too.
+func (m SortedMap[K, V]) MarshalJSON() ([]byte, error) {
+	return marshalObject(m.All())
+}
+
+// Decode a JSON object into the map. Existing entries are kept. null is a no-op.
+func (m *SortedMap[K, V]) UnmarshalJSON(data []byte) error {
+	return unmarshalObject(data, m.Set)
+}
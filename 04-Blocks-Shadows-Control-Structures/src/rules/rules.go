@@ -0,0 +1,230 @@
+// Package rules generalizes FizzBuzz into a configurable rule engine.
+//
+// Each rule matches a number, either by divisor or by a named predicate, and contributes a word.
+// Rules are evaluated by increasing priority. The words of all matching rules are concatenated,
+// unless a matching rule has Stop set, in which case evaluation stops after it.
+// If no rule matches, the number itself is printed.
+//
+// Classic FizzBuzz is two rules: {Divisor: 3, Word: "Fizz"} and {Divisor: 5, Word: "Buzz"}.
+package rules
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// A single rule.
+type Rule struct {
+	// Match numbers divisible by Divisor. Zero means no divisor check.
+	Divisor int `json:"divisor,omitempty"`
+	// Match numbers for which the named predicate is true. Empty means no predicate check.
+	// If both Divisor and Predicate are set, both must match.
+	Predicate string `json:"predicate,omitempty"`
+	// The word printed when the rule matches.
+	Word string `json:"word"`
+	// Rules with a lower priority are evaluated first.
+	Priority int `json:"priority,omitempty"`
+	// Stop evaluating further rules when this one matches.
+	Stop bool `json:"stop,omitempty"`
+}
+
+// The content of a config file.
+type Config struct {
+	Rules []Rule `json:"rules"`
+}
+
+// A named test on a number.
+type Predicate func(int) bool
+
+// Returned when a rule cannot be used.
+var ErrInvalidRule = errors.New("rules: invalid rule")
+
+// Evaluate rules over numbers.
+type Engine struct {
+	rules      []Rule
+	predicates map[string]Predicate
+}
+
+// Create an engine with the built-in predicates: even, odd, prime, square.
+// The rules are sorted by priority, keeping the given order for equal priorities.
+func New(rules ...Rule) (*Engine, error) {
+	e := &Engine{predicates: map[string]Predicate{
+		"even":   func(n int) bool { return n%2 == 0 },
+		"odd":    func(n int) bool { return n%2 != 0 },
+		"prime":  isPrime,
+		"square": isSquare,
+	}}
+	if err := e.SetRules(rules...); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Add or replace a named predicate.
+func (e *Engine) RegisterPredicate(name string, p Predicate) {
+	e.predicates[name] = p
+}
+
+// Replace the rules of the engine.
+func (e *Engine) SetRules(rules ...Rule) error {
+	for i, r := range rules {
+		if r.Divisor == 0 && r.Predicate == "" {
+			return fmt.Errorf("%w: rule %d has neither divisor nor predicate", ErrInvalidRule, i)
+		}
+		if r.Divisor < 0 {
+			return fmt.Errorf("%w: rule %d has a negative divisor", ErrInvalidRule, i)
+		}
+	}
+	e.rules = slices.Clone(rules)
+	slices.SortStableFunc(e.rules, func(a, b Rule) int { return cmp.Compare(a.Priority, b.Priority) })
+	return nil
+}
+
+// Read a JSON config from r and create an engine with its rules.
+func Load(r io.Reader) (*Engine, error) {
+	var cfg Config
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("rules: %w", err)
+	}
+	return New(cfg.Rules...)
+}
+
+// Read a JSON config file and create an engine with its rules.
+func LoadFile(name string) (*Engine, error) {
+	fl, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer fl.Close()
+	return Load(fl)
+}
+
+// Report whether the rule matches n.
+func (e *Engine) matches(r Rule, n int) (bool, error) {
+	if r.Divisor != 0 && n%r.Divisor != 0 {
+		return false, nil
+	}
+	if r.Predicate != "" {
+		p, ok := e.predicates[r.Predicate]
+		if !ok {
+			return false, fmt.Errorf("%w: unknown predicate %q", ErrInvalidRule, r.Predicate)
+		}
+		return p(n), nil
+	}
+	return true, nil
+}
+
+// Return the output for a single number.
+func (e *Engine) Apply(n int) (string, error) {
+	var sb strings.Builder
+	for _, r := range e.rules {
+		ok, err := e.matches(r, n)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			continue
+		}
+		sb.WriteString(r.Word)
+		if r.Stop {
+			break
+		}
+	}
+	if sb.Len() == 0 {
+		return strconv.Itoa(n), nil
+	}
+	return sb.String(), nil
+}
+
+// Return the numbers from lo to hi, excluding hi, like `for i := lo; i < hi; i++`.
+func Range(lo, hi int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := lo; i < hi; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// Read whitespace-separated integers from r until EOF.
+// A token that is not an integer, or a read error, ends the stream and is reported through errp.
+// With a nil errp, the error only ends the stream.
+func Ints(r io.Reader, errp *error) iter.Seq[int] {
+	report := func(err error) {
+		if errp != nil {
+			*errp = err
+		}
+	}
+	return func(yield func(int) bool) {
+		sc := bufio.NewScanner(r)
+		sc.Split(bufio.ScanWords)
+		for sc.Scan() {
+			n, err := strconv.Atoi(sc.Text())
+			if err != nil {
+				report(err)
+				return
+			}
+			if !yield(n) {
+				return
+			}
+		}
+		report(sc.Err())
+	}
+}
+
+// Write the output for every number of seq, each followed by a space,
+// like the FizzBuzz loops of chapter 04.
+func (e *Engine) Write(w io.Writer, seq iter.Seq[int]) error {
+	bw := bufio.NewWriter(w)
+	for n := range seq {
+		out, err := e.Apply(n)
+		if err != nil {
+			return err
+		}
+		bw.WriteString(out)
+		bw.WriteByte(' ')
+	}
+	return bw.Flush()
+}
+
+// Report whether n is a prime number.
+func isPrime(n int) bool {
+	if n < 2 {
+		return false
+	}
+	for d := 2; d*d <= n; d++ {
+		if n%d == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Report whether n is a perfect square.
+func isSquare(n int) bool {
+	if n < 0 {
+		return false
+	}
+	r := int(math.Sqrt(float64(n)))
+	// Correct any rounding error of Sqrt
+	for r*r > n {
+		r--
+	}
+	for (r+1)*(r+1) <= n {
+		r++
+	}
+	return r*r == n
+}
@@ -0,0 +1,177 @@
+package rules_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/maevadevs/Go-Learning/Blocks-Shadows-Control-Structures/src/rules"
+)
+
+// The three FizzBuzz loops of src/main.go, writing to a builder instead of standard output.
+
+func fizzBuzzNested(sb *strings.Builder, lo, hi int) {
+	for i := lo; i < hi; i++ {
+		if i%3 == 0 {
+			if i%5 == 0 {
+				sb.WriteString("FizzBuzz ")
+			} else {
+				sb.WriteString("Fizz ")
+			}
+		} else if i%5 == 0 {
+			sb.WriteString("Buzz ")
+		} else {
+			fmt.Fprintf(sb, "%d ", i)
+		}
+	}
+}
+
+func fizzBuzzContinue(sb *strings.Builder, lo, hi int) {
+	for i := lo; i < hi; i++ {
+		if i%3 == 0 && i%5 == 0 {
+			sb.WriteString("FizzBuzz ")
+			continue
+		}
+		if i%3 == 0 {
+			sb.WriteString("Fizz ")
+			continue
+		}
+		if i%5 == 0 {
+			sb.WriteString("Buzz ")
+			continue
+		}
+		fmt.Fprintf(sb, "%d ", i)
+	}
+}
+
+func fizzBuzzSwitch(sb *strings.Builder, lo, hi int) {
+	for i := lo; i < hi; i++ {
+		switch {
+		case i%3 == 0 && i%5 == 0:
+			sb.WriteString("FizzBuzz ")
+		case i%3 == 0:
+			sb.WriteString("Fizz ")
+		case i%5 == 0:
+			sb.WriteString("Buzz ")
+		default:
+			fmt.Fprintf(sb, "%d ", i)
+		}
+	}
+}
+
+func TestFizzBuzzRegression(t *testing.T) {
+	fromFile, err := rules.LoadFile("../textfiles/fizzbuzz.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromCode, err := rules.New(
+		rules.Rule{Divisor: 3, Word: "Fizz"},
+		rules.Rule{Divisor: 5, Word: "Buzz"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engines := map[string]*rules.Engine{"fizzbuzz.json": fromFile, "New": fromCode}
+	versions := map[string]func(*strings.Builder, int, int){
+		"nested if": fizzBuzzNested,
+		"continue":  fizzBuzzContinue,
+		"switch":    fizzBuzzSwitch,
+	}
+	for engineName, e := range engines {
+		var got strings.Builder
+		if err := e.Write(&got, rules.Range(1, 101)); err != nil {
+			t.Fatal(err)
+		}
+		for name, version := range versions {
+			var want strings.Builder
+			version(&want, 1, 101)
+			if got.String() != want.String() {
+				t.Errorf("engine from %s differs from the %s version:\n%s\nwant:\n%s", engineName, name, got.String(), want.String())
+			}
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	e, err := rules.New(
+		rules.Rule{Predicate: "prime", Word: "Prime", Stop: true},
+		rules.Rule{Divisor: 2, Word: "Even", Priority: 1},
+		rules.Rule{Predicate: "square", Word: "Square", Priority: 1},
+		rules.Rule{Divisor: 3, Predicate: "odd", Word: "OddThree", Priority: 2},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[int]string{
+		1:  "Square",
+		2:  "Prime",
+		4:  "EvenSquare",
+		6:  "Even",
+		9:  "SquareOddThree",
+		10: "Even",
+		11: "Prime",
+		15: "OddThree",
+		25: "Square",
+		35: "35",
+	}
+	for n, want := range tests {
+		if got, err := e.Apply(n); got != want || err != nil {
+			t.Errorf("Apply(%d) = %q, %v, want %q", n, got, err, want)
+		}
+	}
+
+	e.RegisterPredicate("answer", func(n int) bool { return n == 42 })
+	if err := e.SetRules(rules.Rule{Predicate: "answer", Word: "Answer"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := e.Apply(42); got != "Answer" {
+		t.Errorf("Apply(42) with a registered predicate = %q", got)
+	}
+	if err := e.SetRules(rules.Rule{Predicate: "missing", Word: "?"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.Apply(1); !errors.Is(err, rules.ErrInvalidRule) {
+		t.Errorf("Apply with an unknown predicate: err = %v, want ErrInvalidRule", err)
+	}
+}
+
+func TestInvalidRules(t *testing.T) {
+	for _, r := range []rules.Rule{{Word: "Nothing"}, {Divisor: -3, Word: "Negative"}} {
+		if _, err := rules.New(r); !errors.Is(err, rules.ErrInvalidRule) {
+			t.Errorf("New(%+v): err = %v, want ErrInvalidRule", r, err)
+		}
+	}
+	for _, cfg := range []string{`{"rules": [{"divisor": 3, "word": "Fizz", "color": "red"}]}`, `{"rules": [`} {
+		if _, err := rules.Load(strings.NewReader(cfg)); err == nil {
+			t.Errorf("Load(%s): no error", cfg)
+		}
+	}
+}
+
+func TestInts(t *testing.T) {
+	var err error
+	got := slices.Collect(rules.Ints(strings.NewReader("1 2\n\t3  -4"), &err))
+	if !slices.Equal(got, []int{1, 2, 3, -4}) || err != nil {
+		t.Errorf("Ints = %v, %v", got, err)
+	}
+
+	got = slices.Collect(rules.Ints(strings.NewReader("1 x 3"), &err))
+	if !slices.Equal(got, []int{1}) || !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Ints with a bad token = %v, %v, want [1], ErrSyntax", got, err)
+	}
+
+	// A nil errp only ends the stream
+	got = slices.Collect(rules.Ints(strings.NewReader("1 x 3"), nil))
+	if !slices.Equal(got, []int{1}) {
+		t.Errorf("Ints with a nil errp = %v, want [1]", got)
+	}
+
+	e, _ := rules.New(rules.Rule{Divisor: 2, Word: "Even"})
+	var sb strings.Builder
+	if err := e.Write(&sb, rules.Ints(strings.NewReader("1 2 3"), nil)); err != nil || sb.String() != "1 Even 3 " {
+		t.Errorf("Write = %q, %v", sb.String(), err)
+	}
+}
@@ -0,0 +1,46 @@
+package runeinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// Write the report as a table, one rune per row.
+// The first rune of each grapheme cluster shows the cluster number and text.
+func (rep Report) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CLUSTER\tOFFSET\tCHAR\tCODE POINT\tUTF-8\tCAT\tNAME")
+	for i, c := range rep.Clusters {
+		for j, r := range c.Runes {
+			cluster := ""
+			if j == 0 {
+				cluster = fmt.Sprintf("%d %s", i, display(c))
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\t%s\n",
+				cluster, r.Offset, r.Display(), r.CodePoint, r.UTF8, r.Category, r.Name)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w, rep)
+	return err
+}
+
+// A printable form of a cluster: its text, unless it starts with a rune
+// that needs escaping or a combining mark with nothing to combine with.
+func display(c Cluster) string {
+	if first := c.Runes[0]; first.Display() != string(first.Rune) {
+		return first.Display()
+	}
+	return c.Text
+}
+
+// Write the report as indented JSON.
+func (rep Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rep)
+}
@@ -0,0 +1,137 @@
+// Package runeinfo breaks a string down into grapheme clusters, runes, and bytes.
+//
+// Ranging over a string visits its runes, but what a reader sees as one character
+// can be several runes: a letter and its combining accents, or an emoji ZWJ sequence.
+// Those user-perceived characters are the grapheme clusters of Unicode UAX #29.
+package runeinfo
+
+import (
+	"fmt"
+	"slices"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/unicode/runenames"
+)
+
+// One rune of the string, or one byte that is not valid UTF-8.
+type Rune struct {
+	// Byte offset of the rune in the string
+	Offset int `json:"offset"`
+	// Width of the rune in bytes
+	Size int `json:"size"`
+	// The rune itself. utf8.RuneError for an invalid byte.
+	Rune rune `json:"rune"`
+	// Code point in U+XXXX notation, or "invalid"
+	CodePoint string `json:"code_point"`
+	// UTF-8 encoding, as hex bytes: "CF 80"
+	UTF8 string `json:"utf8"`
+	// Unicode general category: "Lu", "Mn", "So"...
+	Category string `json:"category"`
+	// Unicode character name: "GREEK SMALL LETTER PI"
+	Name string `json:"name"`
+	// Whether the bytes are valid UTF-8
+	Valid bool `json:"valid"`
+}
+
+// A printable form of the rune for a table:
+// escapes control characters and puts combining marks on a dotted circle.
+func (r Rune) Display() string {
+	switch {
+	case !r.Valid:
+		return "�"
+	case unicode.In(r.Rune, unicode.Mn, unicode.Me):
+		return "◌" + string(r.Rune)
+	case !unicode.IsGraphic(r.Rune):
+		q := fmt.Sprintf("%+q", r.Rune)
+		return q[1 : len(q)-1]
+	default:
+		return string(r.Rune)
+	}
+}
+
+// One grapheme cluster: a user-perceived character.
+type Cluster struct {
+	// Byte offset of the cluster in the string
+	Offset int `json:"offset"`
+	// The bytes of the cluster
+	Text string `json:"text"`
+	// The runes of the cluster, in order
+	Runes []Rune `json:"runes"`
+}
+
+// A string broken down into clusters.
+type Report struct {
+	Bytes    int       `json:"bytes"`
+	Runes    int       `json:"runes"`
+	Clusters []Cluster `json:"clusters"`
+}
+
+// Break a string down into grapheme clusters and runes.
+func Inspect(s string) Report {
+	rep := Report{Bytes: len(s), Clusters: []Cluster{}}
+	offset, state := 0, -1
+	for rest := s; rest != ""; {
+		var text string
+		text, rest, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		c := Cluster{Offset: offset, Text: text}
+		for i := 0; i < len(text); {
+			r := describe(text[i:], offset+i)
+			c.Runes = append(c.Runes, r)
+			i += r.Size
+		}
+		rep.Runes += len(c.Runes)
+		rep.Clusters = append(rep.Clusters, c)
+		offset += len(text)
+	}
+	return rep
+}
+
+// Describe the first rune of s, found at the given offset.
+func describe(s string, offset int) Rune {
+	rn, size := utf8.DecodeRuneInString(s)
+	r := Rune{
+		Offset: offset,
+		Size:   size,
+		Rune:   rn,
+		UTF8:   fmt.Sprintf("% X", s[:size]),
+		Valid:  rn != utf8.RuneError || size > 1,
+	}
+	if !r.Valid {
+		r.CodePoint = "invalid"
+		return r
+	}
+	r.CodePoint = fmt.Sprintf("%U", rn)
+	r.Category = Category(rn)
+	r.Name = runenames.Name(rn)
+	return r
+}
+
+// Two-letter general categories, sorted so that lookups are deterministic.
+// LC (cased letter) is left out: it is the union of Lu, Ll, and Lt.
+var categories = func() []string {
+	var names []string
+	for name := range unicode.Categories {
+		if len(name) == 2 && name != "LC" {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}()
+
+// Return the Unicode general category of a rune, "Cn" if it is unassigned.
+func Category(r rune) string {
+	for _, name := range categories {
+		if unicode.Is(unicode.Categories[name], r) {
+			return name
+		}
+	}
+	return "Cn"
+}
+
+// Summarize the report: "5 bytes, 4 runes, 4 grapheme clusters"
+func (rep Report) String() string {
+	return fmt.Sprintf("%d bytes, %d runes, %d grapheme clusters", rep.Bytes, rep.Runes, len(rep.Clusters))
+}
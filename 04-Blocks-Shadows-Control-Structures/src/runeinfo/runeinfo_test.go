@@ -0,0 +1,143 @@
+package runeinfo_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/maevadevs/Go-Learning/Blocks-Shadows-Control-Structures/src/runeinfo"
+)
+
+func TestInspect(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		bytes    int
+		runes    int
+		clusters []string
+	}{
+		{"empty", "", 0, 0, nil},
+		{"ASCII", "Go!", 3, 3, []string{"G", "o", "!"}},
+		{"precomposed accent", "\u00e9", 2, 1, []string{"\u00e9"}},
+		{"combining accent", "e\u0301x", 4, 3, []string{"e\u0301", "x"}},
+		{"ZWJ sequence", "👩‍👩‍👧", 18, 5, []string{"👩‍👩‍👧"}},
+		{"flags", "🇫🇷🇯🇵", 16, 4, []string{"🇫🇷", "🇯🇵"}},
+		{"CRLF", "a\r\nb", 4, 4, []string{"a", "\r\n", "b"}},
+		{"invalid byte", "a\xffb", 3, 3, []string{"a", "\xff", "b"}},
+	}
+	for _, tt := range tests {
+		rep := runeinfo.Inspect(tt.s)
+		var clusters []string
+		for _, c := range rep.Clusters {
+			clusters = append(clusters, c.Text)
+		}
+		if rep.Bytes != tt.bytes || rep.Runes != tt.runes || !reflect.DeepEqual(clusters, tt.clusters) {
+			t.Errorf("%s: Inspect(%q) = %d bytes, %d runes, clusters %q, want %d, %d, %q",
+				tt.name, tt.s, rep.Bytes, rep.Runes, clusters, tt.bytes, tt.runes, tt.clusters)
+		}
+	}
+}
+
+func TestRunes(t *testing.T) {
+	rep := runeinfo.Inspect("πe\u0301\xff\t\ufffd")
+	want := []runeinfo.Rune{
+		{Offset: 0, Size: 2, Rune: 'π', CodePoint: "U+03C0", UTF8: "CF 80", Category: "Ll", Name: "GREEK SMALL LETTER PI", Valid: true},
+		{Offset: 2, Size: 1, Rune: 'e', CodePoint: "U+0065", UTF8: "65", Category: "Ll", Name: "LATIN SMALL LETTER E", Valid: true},
+		{Offset: 3, Size: 2, Rune: '\u0301', CodePoint: "U+0301", UTF8: "CC 81", Category: "Mn", Name: "COMBINING ACUTE ACCENT", Valid: true},
+		{Offset: 5, Size: 1, Rune: '�', CodePoint: "invalid", UTF8: "FF"},
+		{Offset: 6, Size: 1, Rune: '\t', CodePoint: "U+0009", UTF8: "09", Category: "Cc", Name: "<control>", Valid: true},
+		// The replacement character itself is valid
+		{Offset: 7, Size: 3, Rune: '�', CodePoint: "U+FFFD", UTF8: "EF BF BD", Category: "So", Name: "REPLACEMENT CHARACTER", Valid: true},
+	}
+	var got []runeinfo.Rune
+	for _, c := range rep.Clusters {
+		got = append(got, c.Runes...)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d runes, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("rune %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDisplay(t *testing.T) {
+	tests := []struct {
+		s, want string
+	}{
+		{"a", "a"},
+		{"\u0301", "◌\u0301"},
+		{"\t", `\t`},
+		{"\u200d", `\u200d`},
+		{"\xff", "�"},
+		{"日", "日"},
+	}
+	for _, tt := range tests {
+		r := runeinfo.Inspect(tt.s).Clusters[0].Runes[0]
+		if got := r.Display(); got != tt.want {
+			t.Errorf("Display(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestCategory(t *testing.T) {
+	tests := map[rune]string{
+		'A':      "Lu",
+		'a':      "Ll",
+		'ǅ':      "Lt",
+		'5':      "Nd",
+		'Ⅻ':      "Nl",
+		' ':      "Zs",
+		'+':      "Sm",
+		'$':      "Sc",
+		'😊':      "So",
+		'\u0301': "Mn",
+		'\u0378': "Cn",
+	}
+	for r, want := range tests {
+		if got := runeinfo.Category(r); got != want {
+			t.Errorf("Category(%U) = %s, want %s", r, got, want)
+		}
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	var sb strings.Builder
+	if err := runeinfo.Inspect("e\u0301\t").WriteTable(&sb); err != nil {
+		t.Fatal(err)
+	}
+	want := "CLUSTER  OFFSET  CHAR  CODE POINT  UTF-8  CAT  NAME\n" +
+		"0 e\u0301     0       e     U+0065      65     Ll   LATIN SMALL LETTER E\n" +
+		"         1       ◌\u0301    U+0301      CC 81  Mn   COMBINING ACUTE ACCENT\n" +
+		"1 \\t     3       \\t    U+0009      09     Cc   <control>\n" +
+		"4 bytes, 3 runes, 2 grapheme clusters\n"
+	if sb.String() != want {
+		t.Errorf("WriteTable:\n%s\nwant:\n%s", sb.String(), want)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	rep := runeinfo.Inspect("añ\xff")
+	var sb strings.Builder
+	if err := rep.WriteJSON(&sb); err != nil {
+		t.Fatal(err)
+	}
+	var decoded runeinfo.Report
+	if err := json.Unmarshal([]byte(sb.String()), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	// The invalid byte is not valid JSON text: it is replaced by U+FFFD
+	rep.Clusters[2].Text = "�"
+	if !reflect.DeepEqual(decoded, rep) {
+		t.Errorf("decoded %+v, want %+v", decoded, rep)
+	}
+	if !strings.Contains(sb.String(), `"code_point": "U+00F1"`) {
+		t.Errorf("JSON without the field names of the tags:\n%s", sb.String())
+	}
+	if got := runeinfo.Inspect("").String(); got != "0 bytes, 0 runes, 0 grapheme clusters" {
+		t.Errorf("String() = %q", got)
+	}
+}
@@ -0,0 +1,179 @@
+// Package shadowcheck defines an analyzer that reports declarations shadowing
+// another identifier that is still in scope:
+//
+//   - Variables, constants and types shadowing a variable of an enclosing block,
+//     like `x := 5` inside an `if` block when `x` is already declared outside.
+//     As with vet's shadow checker, the outer variable must be used after the declaration:
+//     `if err := f(); err != nil` is only reported when the outer err is used later.
+//     Parameters are not reported either. The -strict flag reports every shadowed variable.
+//   - Declarations shadowing an imported package, like `math := "oops!"`.
+//   - Declarations shadowing an identifier of the universe block, like `len := 3` or `true := false`.
+//
+// A report can be suppressed with a `//shadow:ignore` comment on the same line or on the line above.
+// The comment must be exactly the directive, without a space after the slashes.
+package shadowcheck
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// The comment that suppresses a report.
+const ignoreDirective = "//shadow:ignore"
+
+// Reports shadowed variables, imports and universe identifiers.
+var Analyzer = &analysis.Analyzer{
+	Name: "shadowcheck",
+	Doc:  "report declarations that shadow variables, imported packages or universe identifiers",
+	URL:  "https://github.com/maevadevs/Go-Developer-Advanced/tree/main/04-Blocks-Shadows-Control-Structures",
+	Run:  run,
+}
+
+// Flags of the analyzer, also available as -shadowcheck.<name> under go vet.
+var (
+	checkVars     bool
+	checkImports  bool
+	checkUniverse bool
+	strict        bool
+)
+
+func init() {
+	Analyzer.Flags.BoolVar(&checkVars, "vars", true, "report declarations shadowing a variable of an enclosing block")
+	Analyzer.Flags.BoolVar(&checkImports, "imports", true, "report declarations shadowing an imported package")
+	Analyzer.Flags.BoolVar(&checkUniverse, "universe", true, "report declarations shadowing a universe identifier such as len, nil or true")
+	Analyzer.Flags.BoolVar(&strict, "strict", false, "report shadowed variables even when they are not used after the declaration")
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	ignored := ignoredLines(pass)
+	lastUse := lastUses(pass)
+	// Defs is a map: sort the identifiers to report in source order
+	var idents []*ast.Ident
+	for ident, obj := range pass.TypesInfo.Defs {
+		if obj != nil && ident.Name != "_" && declaresName(obj) {
+			idents = append(idents, ident)
+		}
+	}
+	slices.SortFunc(idents, func(a, b *ast.Ident) int { return cmp.Compare(a.Pos(), b.Pos()) })
+	for _, ident := range idents {
+		obj := pass.TypesInfo.Defs[ident]
+		scope := obj.Parent()
+		if scope == nil || scope == types.Universe {
+			continue
+		}
+		outerScope, outer := scope.Parent().LookupParent(ident.Name, ident.Pos())
+		if outer == nil || outer == obj {
+			continue
+		}
+		msg := describe(pass.Fset, ident.Name, outerScope, outer)
+		if msg == "" {
+			continue
+		}
+		if _, ok := outer.(*types.Var); ok && !strict && !confusable(obj, outer, lastUse) {
+			continue
+		}
+		pos := pass.Fset.Position(ident.Pos())
+		if ignored[pos.Filename][pos.Line] {
+			continue
+		}
+		pass.Report(analysis.Diagnostic{Pos: ident.Pos(), End: ident.End(), Message: msg})
+	}
+	return nil, nil
+}
+
+// Report whether obj is a declaration that can shadow another one.
+func declaresName(obj types.Object) bool {
+	switch o := obj.(type) {
+	case *types.Var:
+		// Struct fields live in their own namespace
+		return !o.IsField()
+	case *types.Func:
+		// Methods live in the namespace of their type
+		return o.Signature().Recv() == nil
+	case *types.Const, *types.TypeName:
+		return true
+	default:
+		return false
+	}
+}
+
+// Describe the shadowed declaration, or return "" if it should not be reported.
+func describe(fset *token.FileSet, name string, outerScope *types.Scope, outer types.Object) string {
+	switch o := outer.(type) {
+	case *types.PkgName:
+		if !checkImports {
+			return ""
+		}
+		return fmt.Sprintf("declaration of %q shadows imported package %q", name, o.Imported().Path())
+	case *types.Var:
+		if outerScope == types.Universe || !checkVars {
+			return ""
+		}
+		return fmt.Sprintf("declaration of %q shadows variable declared at %s", name, shortPos(fset, o.Pos()))
+	}
+	if outerScope == types.Universe {
+		if !checkUniverse {
+			return ""
+		}
+		return fmt.Sprintf("declaration of %q shadows predeclared identifier %q", name, name)
+	}
+	return ""
+}
+
+// Print a position as file:line without the directory.
+func shortPos(fset *token.FileSet, pos token.Pos) string {
+	p := fset.Position(pos)
+	file := p.Filename
+	if i := strings.LastIndexAny(file, `/\`); i >= 0 {
+		file = file[i+1:]
+	}
+	return fmt.Sprintf("%s:%d", file, p.Line)
+}
+
+// Report whether obj can be mistaken for the variable outer it shadows.
+// Like vet, parameters are not reported, such as t in t.Run(name, func(t *testing.T) {...}),
+// and neither is a variable that is not used after the declaration.
+func confusable(obj, outer types.Object, lastUse map[types.Object]token.Pos) bool {
+	if v, ok := obj.(*types.Var); ok && (v.Kind() == types.ParamVar || v.Kind() == types.ResultVar) {
+		return false
+	}
+	return lastUse[outer] > obj.Pos()
+}
+
+// Return the position of the last use of each object.
+func lastUses(pass *analysis.Pass) map[types.Object]token.Pos {
+	res := map[types.Object]token.Pos{}
+	for ident, obj := range pass.TypesInfo.Uses {
+		res[obj] = max(res[obj], ident.Pos())
+	}
+	return res
+}
+
+// Return, per file, the lines on which reports are suppressed:
+// the line of each ignore comment and the line below it.
+func ignoredLines(pass *analysis.Pass) map[string]map[int]bool {
+	res := map[string]map[int]bool{}
+	for _, file := range pass.Files {
+		for _, group := range file.Comments {
+			for _, c := range group.List {
+				if c.Text != ignoreDirective {
+					continue
+				}
+				pos := pass.Fset.Position(c.Slash)
+				if res[pos.Filename] == nil {
+					res[pos.Filename] = map[int]bool{}
+				}
+				res[pos.Filename][pos.Line] = true
+				res[pos.Filename][pos.Line+1] = true
+			}
+		}
+	}
+	return res
+}
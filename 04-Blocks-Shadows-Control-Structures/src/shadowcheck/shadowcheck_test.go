@@ -0,0 +1,35 @@
+package shadowcheck_test
+
+import (
+	"testing"
+
+	"github.com/maevadevs/Go-Learning/Blocks-Shadows-Control-Structures/src/shadowcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), shadowcheck.Analyzer, "vars", "imports", "universe", "ignore")
+}
+
+func TestStrict(t *testing.T) {
+	if err := shadowcheck.Analyzer.Flags.Set("strict", "true"); err != nil {
+		t.Fatal(err)
+	}
+	defer shadowcheck.Analyzer.Flags.Set("strict", "false")
+	analysistest.Run(t, analysistest.TestData(), shadowcheck.Analyzer, "strict")
+}
+
+func TestSourceOrder(t *testing.T) {
+	// Defs is a map: without sorting, the order of reports changes between runs
+	for range 3 {
+		results := analysistest.Run(t, analysistest.TestData(), shadowcheck.Analyzer, "vars")
+		for _, res := range results {
+			for i := 1; i < len(res.Diagnostics); i++ {
+				if res.Diagnostics[i-1].Pos > res.Diagnostics[i].Pos {
+					t.Fatalf("diagnostics out of order: %v before %v",
+						res.Pass.Fset.Position(res.Diagnostics[i-1].Pos), res.Pass.Fset.Position(res.Diagnostics[i].Pos))
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package ignore
+
+import "fmt"
+
+func ignored(x int) {
+	if x > 0 {
+		x := 5 //shadow:ignore
+		fmt.Println(x)
+	}
+	if x > 1 {
+		//shadow:ignore
+		x := 6
+		fmt.Println(x)
+	}
+	if x > 2 {
+		x := 7 // want `declaration of "x" shadows variable declared at ignore.go:5`
+		fmt.Println(x)
+	}
+	//shadow:ignore
+
+	if x > 3 {
+		x := 8 // want `declaration of "x" shadows variable declared at ignore.go:5`
+		fmt.Println(x)
+	}
+	// Only the exact directive suppresses a report
+	if x > 4 {
+		x := 9 // shadow:ignore // want `declaration of "x" shadows variable declared at ignore.go:5`
+		fmt.Println(x)
+	}
+	if x > 5 {
+		x := 10 // want `declaration of "x" shadows variable declared at ignore.go:5`
+		fmt.Println(x, "see //shadow:ignore")
+	}
+	fmt.Println(x)
+}
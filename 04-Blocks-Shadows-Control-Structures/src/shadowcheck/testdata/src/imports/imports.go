@@ -0,0 +1,22 @@
+package imports
+
+import (
+	"fmt"
+	"math"
+	str "strings"
+)
+
+func use() {
+	fmt.Println(math.Pi, str.ToUpper("a"))
+}
+
+func shadowImports() {
+	math := "oops!" // want `declaration of "math" shadows imported package "math"`
+	fmt.Println(math)
+	str := 3 // want `declaration of "str" shadows imported package "strings"`
+	fmt.Println(str)
+}
+
+func param(fmt string) string { // want `declaration of "fmt" shadows imported package "fmt"`
+	return fmt
+}
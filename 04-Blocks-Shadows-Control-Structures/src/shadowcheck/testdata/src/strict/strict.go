@@ -0,0 +1,18 @@
+package strict
+
+import "fmt"
+
+// With -strict, the outer variable does not need to be used after the declaration
+func unused(x int) {
+	fmt.Println(x)
+	if x > 0 {
+		x := 5 // want `declaration of "x" shadows variable declared at strict.go:6`
+		fmt.Println(x)
+	}
+}
+
+func params(t int, run func(func(int))) {
+	run(func(t int) { // want `declaration of "t" shadows variable declared at strict.go:14`
+		fmt.Println(t)
+	})
+}
@@ -0,0 +1,15 @@
+package universe
+
+import "fmt"
+
+func shadowUniverse() {
+	len := 3      // want `declaration of "len" shadows predeclared identifier "len"`
+	true := false // want `declaration of "true" shadows predeclared identifier "true"`
+	fmt.Println(len, true)
+}
+
+type string int // want `declaration of "string" shadows predeclared identifier "string"`
+
+func nilParam(nil int) int { // want `declaration of "nil" shadows predeclared identifier "nil"`
+	return nil
+}
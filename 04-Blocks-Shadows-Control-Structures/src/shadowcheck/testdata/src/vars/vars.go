@@ -0,0 +1,82 @@
+package vars
+
+import (
+	"errors"
+	"fmt"
+)
+
+var global = 1
+
+func blocks(x int) {
+	if x > 0 {
+		x := 5 // want `declaration of "x" shadows variable declared at vars.go:10`
+		fmt.Println(x)
+	}
+	for i := 0; i < 3; i++ {
+		global := i // want `declaration of "global" shadows variable declared at vars.go:8`
+		fmt.Println(global)
+	}
+	y := 1
+	func() {
+		y, z := 2, 3 // want `declaration of "y" shadows variable declared at vars.go:19`
+		fmt.Println(y, z)
+	}()
+	fmt.Println(x, y)
+	const c = 1
+	{
+		const c = 2 // not a variable: not reported
+		fmt.Println(c)
+	}
+}
+
+// The outer variables are not read after the declarations: not reported
+func unused() error {
+	err := errors.New("first")
+	if err != nil {
+		fmt.Println(err)
+	}
+	if err := check(); err != nil {
+		return err
+	}
+	m := map[string]int{}
+	if v, ok := m["a"]; ok {
+		fmt.Println(v)
+	}
+	if v, ok := m["b"]; ok {
+		fmt.Println(v)
+	}
+	return nil
+}
+
+// The outer err is returned after the shadowing declaration
+func used() (err error) {
+	err = check()
+	if err == nil {
+		err := check() // want `declaration of "err" shadows variable declared at vars.go:52`
+		fmt.Println(err)
+	}
+	return err
+}
+
+func check() error { return nil }
+
+// Fields and methods live in their own namespace
+type point struct{ x, y int }
+
+func (p point) global() int { return p.x + global }
+
+// Reassignment is not a declaration
+func assign(x int) int {
+	if x > 0 {
+		x = 5
+	}
+	return x
+}
+
+// A parameter of a function literal is not reported
+func params(t int, run func(string, func(int))) {
+	run("sub", func(t int) {
+		fmt.Println(t)
+	})
+	fmt.Println(t)
+}
@@ -0,0 +1,107 @@
+package wordclass
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Read the words of r until EOF, in lower case.
+// A word is a run of letters, marks, and digits, with inner apostrophes: "don't" is one word.
+// A read error is reported through errp and ends the stream.
+// With a nil errp, the error only ends the stream.
+func Words(r io.Reader, errp *error) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		sc := bufio.NewScanner(r)
+		for sc.Scan() {
+			fields := strings.FieldsFunc(sc.Text(), func(rn rune) bool {
+				return !unicode.In(rn, unicode.L, unicode.M, unicode.Nd) && rn != '\'' && rn != '’'
+			})
+			for _, f := range fields {
+				w := strings.Trim(f, "'’")
+				if w == "" {
+					continue
+				}
+				if !yield(strings.ToLower(w)) {
+					return
+				}
+			}
+		}
+		if err := sc.Err(); errp != nil {
+			*errp = err
+		}
+	}
+}
+
+// The number of words in a class.
+type ClassCount struct {
+	Class string
+	Count int
+}
+
+// The frequency of each class of a dimension.
+type Histogram struct {
+	Dimension string
+	// In the order of the rules, then the default class
+	Classes []ClassCount
+	Total   int
+}
+
+// Classify every word of seq and count the words of each class, per dimension.
+// Every class of a rule is listed, even without words; the default class only when it has words.
+func (c *Classifier) Count(seq iter.Seq[string]) []Histogram {
+	hists := make([]Histogram, len(c.dims))
+	for i, d := range c.dims {
+		hists[i].Dimension = d.Name
+		for _, r := range d.Rules {
+			hists[i].add(r.Class, 0)
+		}
+	}
+	for word := range seq {
+		for i, class := range c.Classify(word) {
+			hists[i].add(class, 1)
+			hists[i].Total++
+		}
+	}
+	return hists
+}
+
+// Add n to the count of a class, appending the class if it is new.
+// Several rules can give the same class.
+func (h *Histogram) add(class string, n int) {
+	i := slices.IndexFunc(h.Classes, func(cc ClassCount) bool { return cc.Class == class })
+	if i < 0 {
+		h.Classes = append(h.Classes, ClassCount{Class: class})
+		i = len(h.Classes) - 1
+	}
+	h.Classes[i].Count += n
+}
+
+// Write the histogram with bars of at most width characters.
+//
+//	length (9 words)
+//	  short       3   33.3%  ##########
+//	  right       2   22.2%  ######
+func (h Histogram) Write(w io.Writer, width int) error {
+	most, pad := 0, 0
+	for _, cc := range h.Classes {
+		most = max(most, cc.Count)
+		pad = max(pad, utf8.RuneCountInString(cc.Class))
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s (%d words)\n", h.Dimension, h.Total)
+	for _, cc := range h.Classes {
+		pct, bar := 0.0, 0
+		if h.Total > 0 {
+			pct = 100 * float64(cc.Count) / float64(h.Total)
+			bar = cc.Count * width / most
+		}
+		fmt.Fprintf(bw, "  %-*s  %6d  %5.1f%%  %s\n", pad, cc.Class, cc.Count, pct, strings.Repeat("#", bar))
+	}
+	return bw.Flush()
+}
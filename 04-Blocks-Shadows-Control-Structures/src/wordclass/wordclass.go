@@ -0,0 +1,259 @@
+// Package wordclass generalizes the word-length switch into configurable classification rules.
+//
+// A classifier has one or more dimensions, such as length or vowels. Within a dimension,
+// the rules are tried in order and the first matching rule gives the class of the word,
+// like the cases of a switch. Words that match no rule get the default class.
+//
+// A rule has a kind: length (in runes), vowels (ratio of vowels among the letters),
+// or dictionary (membership in a word list). More kinds can be registered.
+package wordclass
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A single rule.
+type Rule struct {
+	// The class given to the words that match.
+	Class string `json:"class"`
+	// How words are matched: length, vowels, dictionary, or a registered kind.
+	Kind string `json:"kind"`
+	// Match words whose measure is at least Min.
+	Min float64 `json:"min,omitempty"`
+	// Match words whose measure is at most Max. Nil means no upper bound:
+	// a "max" of 0 in a config file is a bound, which matches only 0 with a Min of 0.
+	Max *float64 `json:"max,omitempty"`
+	// The word list of a dictionary rule.
+	Words []string `json:"words,omitempty"`
+	// A file with more words for a dictionary rule, one per line.
+	// When loaded from a config file, a relative path is relative to the config file.
+	File string `json:"file,omitempty"`
+}
+
+// A named set of rules. Each word gets exactly one class per dimension.
+type Dimension struct {
+	Name  string `json:"name"`
+	Rules []Rule `json:"rules"`
+	// The class of the words that match no rule. Empty means "other".
+	Default string `json:"default,omitempty"`
+}
+
+// The content of a config file.
+type Config struct {
+	Dimensions []Dimension `json:"dimensions"`
+}
+
+// Report whether a word matches a rule.
+type Matcher func(word string) bool
+
+// Build the matcher of a rule.
+type Kind func(r Rule) (Matcher, error)
+
+// Returned when a rule cannot be used.
+var ErrInvalidRule = errors.New("wordclass: invalid rule")
+
+// The dimension of the word-length switch: the byte-length thresholds become rune-length ranges.
+var DefaultConfig = Config{Dimensions: []Dimension{{
+	Name: "length",
+	Rules: []Rule{
+		{Class: "short", Kind: "length", Min: 1, Max: new(4.0)},
+		{Class: "right", Kind: "length", Min: 5, Max: new(5.0)},
+		{Class: "long", Kind: "length", Min: 6, Max: new(9.0)},
+		{Class: "too long", Kind: "length", Min: 10},
+	},
+}}}
+
+// A compiled dimension.
+type dimension struct {
+	Dimension
+	matchers []Matcher
+}
+
+// Classify words.
+type Classifier struct {
+	dims  []dimension
+	kinds map[string]Kind
+}
+
+// Create a classifier with the built-in kinds: length, vowels, dictionary.
+func New(dims ...Dimension) (*Classifier, error) {
+	c := &Classifier{kinds: map[string]Kind{
+		"length":     measureKind(RuneLength),
+		"vowels":     measureKind(VowelRatio),
+		"dictionary": dictionaryKind,
+	}}
+	if err := c.SetDimensions(dims...); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Add or replace a kind of rule. Call SetDimensions afterward to use it.
+func (c *Classifier) RegisterKind(name string, k Kind) {
+	c.kinds[name] = k
+}
+
+// Replace the dimensions of the classifier.
+func (c *Classifier) SetDimensions(dims ...Dimension) error {
+	compiled := make([]dimension, 0, len(dims))
+	for _, d := range dims {
+		if d.Name == "" {
+			return fmt.Errorf("%w: dimension without a name", ErrInvalidRule)
+		}
+		if d.Default == "" {
+			d.Default = "other"
+		}
+		cd := dimension{Dimension: d}
+		for i, r := range d.Rules {
+			if r.Class == "" {
+				return fmt.Errorf("%w: %s rule %d has no class", ErrInvalidRule, d.Name, i)
+			}
+			kind, ok := c.kinds[r.Kind]
+			if !ok {
+				return fmt.Errorf("%w: %s rule %d has unknown kind %q", ErrInvalidRule, d.Name, i, r.Kind)
+			}
+			m, err := kind(r)
+			if err != nil {
+				return fmt.Errorf("%s rule %d: %w", d.Name, i, err)
+			}
+			cd.matchers = append(cd.matchers, m)
+		}
+		compiled = append(compiled, cd)
+	}
+	c.dims = compiled
+	return nil
+}
+
+// Read a JSON config from r and create a classifier with its dimensions.
+func Load(r io.Reader) (*Classifier, error) {
+	cfg, err := decodeConfig(r)
+	if err != nil {
+		return nil, err
+	}
+	return New(cfg.Dimensions...)
+}
+
+// Read a JSON config file and create a classifier with its dimensions.
+// Dictionary files are relative to the directory of the config file.
+func LoadFile(name string) (*Classifier, error) {
+	fl, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer fl.Close()
+	cfg, err := decodeConfig(fl)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range cfg.Dimensions {
+		for i, r := range d.Rules {
+			if r.File != "" && !filepath.IsAbs(r.File) {
+				d.Rules[i].File = filepath.Join(filepath.Dir(name), r.File)
+			}
+		}
+	}
+	return New(cfg.Dimensions...)
+}
+
+// Decode a JSON config, rejecting unknown fields.
+func decodeConfig(r io.Reader) (Config, error) {
+	var cfg Config
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return cfg, fmt.Errorf("wordclass: %w", err)
+	}
+	return cfg, nil
+}
+
+// Return the class of a word in each dimension, in the order of the dimensions.
+func (c *Classifier) Classify(word string) []string {
+	classes := make([]string, len(c.dims))
+	for i, d := range c.dims {
+		classes[i] = d.classify(word)
+	}
+	return classes
+}
+
+// Return the class of the first matching rule, or the default class.
+func (d dimension) classify(word string) string {
+	for i, m := range d.matchers {
+		if m(word) {
+			return d.Rules[i].Class
+		}
+	}
+	return d.Default
+}
+
+// Return the number of runes in a word.
+func RuneLength(word string) float64 {
+	return float64(utf8.RuneCountInString(word))
+}
+
+// Return the ratio of vowels among the letters of a word, 0 if it has no letters.
+func VowelRatio(word string) float64 {
+	letters, vowels := 0, 0
+	for _, r := range word {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if strings.ContainsRune("aeiouyàâäéèêëîïôöùûüÿ", unicode.ToLower(r)) {
+			vowels++
+		}
+	}
+	if letters == 0 {
+		return 0
+	}
+	return float64(vowels) / float64(letters)
+}
+
+// Make a kind that matches the words whose measure is within [Min, Max].
+func measureKind(measure func(string) float64) Kind {
+	return func(r Rule) (Matcher, error) {
+		if r.Max != nil && *r.Max < r.Min {
+			return nil, fmt.Errorf("%w: max %v is less than min %v", ErrInvalidRule, *r.Max, r.Min)
+		}
+		return func(word string) bool {
+			v := measure(word)
+			return v >= r.Min && (r.Max == nil || v <= *r.Max)
+		}, nil
+	}
+}
+
+// Match the words of the rule's word list and file, ignoring case.
+func dictionaryKind(r Rule) (Matcher, error) {
+	words := map[string]bool{}
+	for _, w := range r.Words {
+		words[strings.ToLower(w)] = true
+	}
+	if r.File != "" {
+		fl, err := os.Open(r.File)
+		if err != nil {
+			return nil, err
+		}
+		defer fl.Close()
+		sc := bufio.NewScanner(fl)
+		for sc.Scan() {
+			if w := strings.TrimSpace(sc.Text()); w != "" && !strings.HasPrefix(w, "#") {
+				words[strings.ToLower(w)] = true
+			}
+		}
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("%w: dictionary without words", ErrInvalidRule)
+	}
+	return func(word string) bool { return words[strings.ToLower(word)] }, nil
+}
@@ -0,0 +1,171 @@
+package wordclass_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/maevadevs/Go-Learning/Blocks-Shadows-Control-Structures/src/wordclass"
+)
+
+func TestDefaultConfig(t *testing.T) {
+	c, err := wordclass.New(wordclass.DefaultConfig.Dimensions...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]string{
+		"":           "other",
+		"go":         "short",
+		"naïve":      "right",
+		"switch":     "long",
+		"statements": "too long",
+		"日本語":        "short",
+	}
+	for word, want := range tests {
+		if got := c.Classify(word); len(got) != 1 || got[0] != want {
+			t.Errorf("Classify(%q) = %v, want %s", word, got, want)
+		}
+	}
+}
+
+func TestBounds(t *testing.T) {
+	c, err := wordclass.New(wordclass.Dimension{
+		Name: "vowels",
+		Rules: []wordclass.Rule{
+			{Class: "none", Kind: "vowels", Max: new(0.0)},
+			{Class: "few", Kind: "vowels", Max: new(0.3)},
+			{Class: "many", Kind: "vowels", Min: 0.6},
+		},
+		Default: "balanced",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]string{
+		"rhythm": "few",
+		"tsk":    "none",
+		"42":     "none",
+		"strand": "few",
+		"table":  "balanced",
+		"audio":  "many",
+	}
+	for word, want := range tests {
+		if got := c.Classify(word); got[0] != want {
+			t.Errorf("Classify(%q) = %s, want %s", word, got[0], want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	// A max of 0 in JSON is a bound, and no max is no bound
+	cfg := `{"dimensions": [{"name": "length", "rules": [
+		{"class": "empty", "kind": "length", "max": 0},
+		{"class": "any", "kind": "length", "min": 1}
+	]}]}`
+	c, err := wordclass.Load(strings.NewReader(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for word, want := range map[string]string{"": "empty", "a": "any", strings.Repeat("a", 100): "any"} {
+		if got := c.Classify(word); got[0] != want {
+			t.Errorf("Classify(%q) = %s, want %s", word, got[0], want)
+		}
+	}
+
+	c, err = wordclass.LoadFile("../textfiles/wordclass.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"short", "consonant-heavy", "go keyword"}
+	if got := c.Classify("func"); !slices.Equal(got, want) {
+		t.Errorf("Classify(func) = %v, want %v", got, want)
+	}
+	if got := c.Classify("shadow"); got[2] != "control structure" {
+		t.Errorf("Classify(shadow) = %v", got)
+	}
+}
+
+func TestInvalidRules(t *testing.T) {
+	tests := []struct {
+		name string
+		dim  wordclass.Dimension
+	}{
+		{"no name", wordclass.Dimension{Rules: []wordclass.Rule{{Class: "a", Kind: "length"}}}},
+		{"no class", wordclass.Dimension{Name: "d", Rules: []wordclass.Rule{{Kind: "length"}}}},
+		{"unknown kind", wordclass.Dimension{Name: "d", Rules: []wordclass.Rule{{Class: "a", Kind: "color"}}}},
+		{"max below min", wordclass.Dimension{Name: "d", Rules: []wordclass.Rule{{Class: "a", Kind: "length", Min: 3, Max: new(2.0)}}}},
+		{"max 0 below min", wordclass.Dimension{Name: "d", Rules: []wordclass.Rule{{Class: "a", Kind: "length", Min: 1, Max: new(0.0)}}}},
+		{"empty dictionary", wordclass.Dimension{Name: "d", Rules: []wordclass.Rule{{Class: "a", Kind: "dictionary"}}}},
+	}
+	for _, tt := range tests {
+		if _, err := wordclass.New(tt.dim); !errors.Is(err, wordclass.ErrInvalidRule) {
+			t.Errorf("%s: err = %v, want ErrInvalidRule", tt.name, err)
+		}
+	}
+	if _, err := wordclass.Load(strings.NewReader(`{"dimensions": [], "colors": []}`)); err == nil {
+		t.Error("Load with an unknown field: no error")
+	}
+}
+
+func TestRegisterKind(t *testing.T) {
+	c, err := wordclass.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.RegisterKind("palindrome", func(wordclass.Rule) (wordclass.Matcher, error) {
+		return func(word string) bool {
+			r := []rune(word)
+			slices.Reverse(r)
+			return string(r) == word
+		}, nil
+	})
+	dir := t.TempDir()
+	if err = os.WriteFile(filepath.Join(dir, "words.txt"), []byte("# Greetings\nHello\n\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err = c.SetDimensions(wordclass.Dimension{Name: "shape", Rules: []wordclass.Rule{
+		{Class: "palindrome", Kind: "palindrome"},
+		{Class: "greeting", Kind: "dictionary", File: filepath.Join(dir, "words.txt")},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for word, want := range map[string]string{"level": "palindrome", "HELLO": "greeting", "# greetings": "other"} {
+		if got := c.Classify(word); got[0] != want {
+			t.Errorf("Classify(%q) = %s, want %s", word, got[0], want)
+		}
+	}
+}
+
+func TestCount(t *testing.T) {
+	c, _ := wordclass.New(wordclass.DefaultConfig.Dimensions...)
+	var err error
+	words := wordclass.Words(strings.NewReader("Don't shadow the 'outer' variables, naïvely!\n42"), &err)
+	hists := c.Count(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []wordclass.ClassCount{{"short", 2}, {"right", 2}, {"long", 3}, {"too long", 0}}
+	if len(hists) != 1 || hists[0].Total != 7 || !slices.Equal(hists[0].Classes, want) {
+		t.Errorf("Count = %+v, want %v", hists, want)
+	}
+}
+
+func TestWordsError(t *testing.T) {
+	errRead := errors.New("read failed")
+	r := io.MultiReader(strings.NewReader("one two\n"), iotest.ErrReader(errRead))
+	var err error
+	if got := slices.Collect(wordclass.Words(r, &err)); !slices.Equal(got, []string{"one", "two"}) || !errors.Is(err, errRead) {
+		t.Errorf("Words = %v, %v, want one two and the read error", got, err)
+	}
+	// Without errp, the error only ends the stream
+	r = io.MultiReader(strings.NewReader("three\n"), iotest.ErrReader(errRead))
+	if got := slices.Collect(wordclass.Words(r, nil)); !slices.Equal(got, []string{"three"}) {
+		t.Errorf("Words with a nil errp = %v", got)
+	}
+}
@@ -0,0 +1,180 @@
+// Package apperr defines the errors reported by the examples,
+// and a registry that maps each of them to a stable, machine-readable code.
+//
+// Callers should compare errors with errors.Is against the sentinels,
+// and use errors.As to get the operands out of an OpError or an ExprError,
+// instead of comparing error strings.
+package apperr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/maevadevs/Go-Learning/Functions/src/checked"
+)
+
+// Sentinel Errors
+// ---------------
+
+var (
+	// Shared with the checked and division packages.
+	ErrDivideByZero = checked.ErrDivideByZero
+	// Shared with the checked and division packages.
+	ErrOverflow            = checked.ErrOverflow
+	ErrUnsupportedOperator = errors.New("unsupported operator")
+	ErrInvalidOperand      = errors.New("invalid operand")
+	ErrInvalidExpression   = errors.New("invalid expression")
+	ErrMissingArgument     = errors.New("missing argument")
+)
+
+// Structured Errors
+// -----------------
+
+// An operation that failed, with the operands it was called with.
+type OpError struct {
+	Op       string
+	Operands []any
+	Err      error
+}
+
+// Print the operation, its operands and the cause: divmod(5, 0): cannot divide by 0
+func (e *OpError) Error() string {
+	args := make([]string, len(e.Operands))
+	for i, o := range e.Operands {
+		args[i] = fmt.Sprint(o)
+	}
+	return fmt.Sprintf("%s(%s): %v", e.Op, strings.Join(args, ", "), e.Err)
+}
+
+// Return the cause.
+func (e *OpError) Unwrap() error { return e.Err }
+
+// An expression of the calculator that could not be evaluated.
+type ExprError struct {
+	Expr []string
+	Err  error
+}
+
+// Print the expression and the cause: [2 ^ 3]: unsupported operator
+func (e *ExprError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Expr, e.Err)
+}
+
+// Return the cause.
+func (e *ExprError) Unwrap() error { return e.Err }
+
+// Error-Code Registry
+// -------------------
+
+// A stable identifier for a kind of error.
+// The zero Code stands for no error.
+type Code string
+
+const (
+	CodeUnknown             Code = "E_UNKNOWN"
+	CodeDivideByZero        Code = "E_DIV_ZERO"
+	CodeOverflow            Code = "E_OVERFLOW"
+	CodeUnsupportedOperator Code = "E_UNSUPPORTED_OP"
+	CodeInvalidOperand      Code = "E_INVALID_OPERAND"
+	CodeInvalidExpression   Code = "E_INVALID_EXPR"
+	CodeMissingArgument     Code = "E_MISSING_ARG"
+	CodeFileNotFound        Code = "E_FILE_NOT_FOUND"
+	CodeIO                  Code = "E_IO"
+)
+
+// A registered code and the sentinel error it stands for.
+type Entry struct {
+	Code        Code
+	Sentinel    error
+	Description string
+}
+
+// The registered codes, checked in order.
+var registry = []Entry{
+	{CodeDivideByZero, ErrDivideByZero, "The divisor is 0"},
+	{CodeOverflow, ErrOverflow, "The result does not fit in the integer type"},
+	{CodeUnsupportedOperator, ErrUnsupportedOperator, "The operator is not supported by the calculator"},
+	{CodeInvalidOperand, ErrInvalidOperand, "An operand is not a valid integer"},
+	{CodeInvalidExpression, ErrInvalidExpression, "The expression does not have the form: operand operator operand"},
+	{CodeMissingArgument, ErrMissingArgument, "A required command-line argument is missing"},
+	{CodeFileNotFound, fs.ErrNotExist, "The file does not exist"},
+}
+
+// Add a code for a sentinel error.
+// Codes registered later are checked after the built-in ones.
+func Register(code Code, sentinel error, description string) {
+	registry = append(registry, Entry{code, sentinel, description})
+}
+
+// Return the registry entry of a code.
+func Lookup(code Code) (Entry, bool) {
+	for _, e := range registry {
+		if e.Code == code {
+			return e, true
+		}
+	}
+	return Entry{}, false
+}
+
+// Return the code of the first registered sentinel that err matches with errors.Is.
+// Other file-system errors get CodeIO, anything else CodeUnknown, and nil the zero Code.
+func CodeOf(err error) Code {
+	if err == nil {
+		return ""
+	}
+	for _, e := range registry {
+		if errors.Is(err, e.Sentinel) {
+			return e.Code
+		}
+	}
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return CodeIO
+	}
+	return CodeUnknown
+}
+
+// A machine-readable description of an error.
+type Report struct {
+	Code     Code     `json:"code"`
+	Message  string   `json:"message"`
+	Op       string   `json:"op,omitempty"`
+	Operands []any    `json:"operands,omitempty"`
+	Expr     []string `json:"expr,omitempty"`
+}
+
+// Describe an error with its code and, if available, its operation or expression.
+// A nil error gives the zero Report.
+func Describe(err error) Report {
+	if err == nil {
+		return Report{}
+	}
+	r := Report{Code: CodeOf(err), Message: err.Error()}
+	var opErr *OpError
+	if errors.As(err, &opErr) {
+		r.Op, r.Operands = opErr.Op, opErr.Operands
+	}
+	var exprErr *ExprError
+	if errors.As(err, &exprErr) {
+		r.Expr = exprErr.Expr
+	}
+	return r
+}
+
+// Print the report as "CODE: message".
+func (r Report) String() string {
+	return fmt.Sprintf("%s: %s", r.Code, r.Message)
+}
+
+// Encode the report as JSON.
+func (r Report) JSON() string {
+	data, err := json.Marshal(r)
+	if err != nil {
+		// Operands that cannot be encoded: fall back to the message only
+		data, _ = json.Marshal(Report{Code: r.Code, Message: r.Message})
+	}
+	return string(data)
+}
@@ -0,0 +1,105 @@
+package apperr_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/maevadevs/Go-Learning/Functions/src/apperr"
+	"github.com/maevadevs/Go-Learning/Functions/src/checked"
+)
+
+func TestCodeOf(t *testing.T) {
+	_, statErr := os.Stat("does/not/exist")
+	tests := []struct {
+		name string
+		err  error
+		want apperr.Code
+	}{
+		{"nil", nil, ""},
+		{"sentinel", apperr.ErrInvalidOperand, apperr.CodeInvalidOperand},
+		{"shared with checked", checked.ErrOverflow, apperr.CodeOverflow},
+		{"wrapped", fmt.Errorf("parse: %w", apperr.ErrMissingArgument), apperr.CodeMissingArgument},
+		{"in an OpError", &apperr.OpError{Op: "divmod", Err: apperr.ErrDivideByZero}, apperr.CodeDivideByZero},
+		{"missing file", statErr, apperr.CodeFileNotFound},
+		{"other path error", &fs.PathError{Op: "read", Path: "f", Err: fs.ErrPermission}, apperr.CodeIO},
+		{"unknown", errors.New("boom"), apperr.CodeUnknown},
+	}
+	for _, tt := range tests {
+		if got := apperr.CodeOf(tt.err); got != tt.want {
+			t.Errorf("%s: CodeOf(%v) = %q, want %q", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	if e, ok := apperr.Lookup(apperr.CodeOverflow); !ok || e.Sentinel != apperr.ErrOverflow {
+		t.Errorf("Lookup(%s) = %+v, %v", apperr.CodeOverflow, e, ok)
+	}
+	if _, ok := apperr.Lookup("E_TIMEOUT"); ok {
+		t.Error("Lookup of an unregistered code succeeded")
+	}
+
+	errTimeout := errors.New("timeout")
+	apperr.Register("E_TIMEOUT", errTimeout, "The operation took too long")
+	if e, ok := apperr.Lookup("E_TIMEOUT"); !ok || e.Sentinel != errTimeout || e.Description != "The operation took too long" {
+		t.Errorf("Lookup(E_TIMEOUT) = %+v, %v", e, ok)
+	}
+	if got := apperr.CodeOf(fmt.Errorf("fetch: %w", errTimeout)); got != "E_TIMEOUT" {
+		t.Errorf("CodeOf of a registered sentinel = %q", got)
+	}
+
+	// The built-in codes are checked first
+	apperr.Register("E_ZERO", apperr.ErrDivideByZero, "Registered twice")
+	if got := apperr.CodeOf(apperr.ErrDivideByZero); got != apperr.CodeDivideByZero {
+		t.Errorf("CodeOf(ErrDivideByZero) = %q after a second registration", got)
+	}
+}
+
+func TestAs(t *testing.T) {
+	opErr := &apperr.OpError{Op: "divmod", Operands: []any{5, 0}, Err: apperr.ErrDivideByZero}
+	err := &apperr.ExprError{Expr: []string{"5", "/", "0"}, Err: fmt.Errorf("eval: %w", opErr)}
+	if got, want := err.Error(), "[5 / 0]: eval: divmod(5, 0): cannot divide by 0"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	var gotOp *apperr.OpError
+	if !errors.As(err, &gotOp) || gotOp != opErr {
+		t.Errorf("errors.As(*OpError) = %v", gotOp)
+	}
+	var gotExpr *apperr.ExprError
+	if !errors.As(fmt.Errorf("calc: %w", err), &gotExpr) || gotExpr != err {
+		t.Errorf("errors.As(*ExprError) = %v", gotExpr)
+	}
+	if !errors.Is(err, apperr.ErrDivideByZero) {
+		t.Error("the sentinel is not found through both errors")
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	opErr := &apperr.OpError{Op: "divmod", Operands: []any{5, 0}, Err: apperr.ErrDivideByZero}
+	r := apperr.Describe(&apperr.ExprError{Expr: []string{"5", "/", "0"}, Err: opErr})
+	if r.Code != apperr.CodeDivideByZero || r.Op != "divmod" || !slices.Equal(r.Operands, []any{5, 0}) || !slices.Equal(r.Expr, []string{"5", "/", "0"}) {
+		t.Errorf("Describe = %+v", r)
+	}
+	want := `{"code":"E_DIV_ZERO","message":"[5 / 0]: divmod(5, 0): cannot divide by 0","op":"divmod","operands":[5,0],"expr":["5","/","0"]}`
+	if got := r.JSON(); got != want {
+		t.Errorf("JSON() = %s, want %s", got, want)
+	}
+	if got := apperr.Describe(apperr.ErrInvalidOperand).String(); got != "E_INVALID_OPERAND: invalid operand" {
+		t.Errorf("String() = %q", got)
+	}
+
+	// Operands that cannot be encoded leave the message only
+	r = apperr.Describe(&apperr.OpError{Op: "send", Operands: []any{make(chan int)}, Err: apperr.ErrInvalidOperand})
+	if got := r.JSON(); got != `{"code":"E_INVALID_OPERAND","message":"`+r.Message+`"}` {
+		t.Errorf("JSON() with a channel operand = %s", got)
+	}
+
+	if r := apperr.Describe(nil); r.Code != "" || r.Message != "" || r.Op != "" || r.Operands != nil || r.Expr != nil {
+		t.Errorf("Describe(nil) = %+v, want the zero Report", r)
+	}
+}
@@ -0,0 +1,203 @@
+// Package calltrace records function entries, exits and events as a call tree,
+// with timings and the argument values captured at the time of the call.
+//
+// It is meant to make the order of deferred calls visible:
+//
+//	func deferExample(t *calltrace.Tracer) {
+//		defer t.Enter("deferExample")()
+//		a := 10
+//		defer func(val int) {
+//			defer t.Enter("first deferred func", "val", val)()
+//		}(a)
+//		...
+//	}
+//
+// A Tracer follows a single goroutine. The call tree can be printed as text,
+// or exported as Chrome trace-event JSON to open in a trace viewer such as chrome://tracing or Perfetto.
+package calltrace
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A key-value pair captured with a span or an event.
+type Arg struct {
+	Key   string
+	Value any
+}
+
+// A function call, or an instant event if it has no duration.
+type Span struct {
+	Name     string
+	Args     []Arg
+	Start    time.Time
+	Duration time.Duration
+	// Whether this is an instant event rather than a call.
+	Instant  bool
+	Children []*Span
+	parent   *Span
+}
+
+// Record a call tree. A Tracer must only be used by a single goroutine:
+// the tree assumes that each call is exited before its caller,
+// which does not hold for calls made by several goroutines.
+type Tracer struct {
+	mu    sync.Mutex
+	now   func() time.Time
+	root  Span
+	cur   *Span
+	start time.Time
+}
+
+// Create a tracer that reads the time from now. A nil now means time.Now.
+// Tests can pass a fake clock to get deterministic timings.
+func New(now func() time.Time) *Tracer {
+	if now == nil {
+		now = time.Now
+	}
+	t := &Tracer{now: now}
+	t.start = now()
+	t.root.Start = t.start
+	t.cur = &t.root
+	return t
+}
+
+// Turn alternating keys and values into a list of Arg.
+func pairs(kv []any) []Arg {
+	args := make([]Arg, 0, (len(kv)+1)/2)
+	for i := 0; i < len(kv); i += 2 {
+		key := fmt.Sprint(kv[i])
+		var val any
+		if i+1 < len(kv) {
+			val = kv[i+1]
+		}
+		args = append(args, Arg{key, val})
+	}
+	return args
+}
+
+// Record the entry into a function, with alternating argument names and values.
+// Returns the function that records the exit: call it with defer.
+//
+//	defer t.Enter("divmod", "num", num, "den", den)()
+func (t *Tracer) Enter(name string, kv ...any) func() {
+	t.mu.Lock()
+	sp := &Span{Name: name, Args: pairs(kv), Start: t.now(), parent: t.cur}
+	t.cur.Children = append(t.cur.Children, sp)
+	t.cur = sp
+	t.mu.Unlock()
+
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		sp.Duration = t.now().Sub(sp.Start)
+		// Pop back to the parent, even if inner spans were not exited
+		t.cur = sp.parent
+	}
+}
+
+// Record an instant event in the current function, with alternating names and values.
+func (t *Tracer) Event(name string, kv ...any) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cur.Children = append(t.cur.Children, &Span{
+		Name:    name,
+		Args:    pairs(kv),
+		Start:   t.now(),
+		Instant: true,
+		parent:  t.cur,
+	})
+}
+
+// Return the top-level spans.
+func (t *Tracer) Spans() []*Span {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.root.Children
+}
+
+// Write the call tree, one span per line, indented by depth.
+func (t *Tracer) WriteTree(w io.Writer) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var sb strings.Builder
+	var walk func(spans []*Span, depth int)
+	walk = func(spans []*Span, depth int) {
+		for _, sp := range spans {
+			sb.WriteString(strings.Repeat("  ", depth))
+			if sp.Instant {
+				sb.WriteString("* ")
+			} else {
+				sb.WriteString("> ")
+			}
+			sb.WriteString(sp.Name)
+			for _, a := range sp.Args {
+				fmt.Fprintf(&sb, " %s=%v", a.Key, a.Value)
+			}
+			if !sp.Instant {
+				fmt.Fprintf(&sb, " (%v)", sp.Duration)
+			}
+			sb.WriteByte('\n')
+			walk(sp.Children, depth+1)
+		}
+	}
+	walk(t.root.Children, 0)
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// A single entry of the Chrome trace-event format.
+type chromeEvent struct {
+	Name  string         `json:"name"`
+	Phase string         `json:"ph"`
+	TS    float64        `json:"ts"`
+	Dur   *float64       `json:"dur,omitempty"`
+	Scope string         `json:"s,omitempty"`
+	PID   int            `json:"pid"`
+	TID   int            `json:"tid"`
+	Args  map[string]any `json:"args,omitempty"`
+}
+
+// Write the call tree as Chrome trace-event JSON.
+// Calls become complete events ("X") and instant events become "i" events.
+// Timestamps are in microseconds since the tracer was created.
+func (t *Tracer) WriteChromeJSON(w io.Writer) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	micros := func(d time.Duration) float64 { return float64(d) / float64(time.Microsecond) }
+	events := []chromeEvent{}
+	var walk func(spans []*Span)
+	walk = func(spans []*Span) {
+		for _, sp := range spans {
+			ev := chromeEvent{Name: sp.Name, TS: micros(sp.Start.Sub(t.start)), PID: 1, TID: 1}
+			if sp.Instant {
+				ev.Phase, ev.Scope = "i", "t"
+			} else {
+				dur := micros(sp.Duration)
+				ev.Phase, ev.Dur = "X", &dur
+			}
+			if len(sp.Args) > 0 {
+				ev.Args = map[string]any{}
+				for _, a := range sp.Args {
+					// Values that JSON cannot encode are stored as text
+					if _, err := json.Marshal(a.Value); err != nil {
+						ev.Args[a.Key] = fmt.Sprint(a.Value)
+					} else {
+						ev.Args[a.Key] = a.Value
+					}
+				}
+			}
+			events = append(events, ev)
+			walk(sp.Children)
+		}
+	}
+	walk(t.root.Children)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(map[string]any{"traceEvents": events, "displayTimeUnit": "ms"})
+}
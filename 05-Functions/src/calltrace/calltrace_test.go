@@ -0,0 +1,119 @@
+package calltrace_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maevadevs/Go-Learning/Functions/src/calltrace"
+)
+
+// Return a clock that moves forward by 1ms at each reading.
+func fakeClock() func() time.Time {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		now = now.Add(time.Millisecond)
+		return now
+	}
+}
+
+// The deferred calls of the package example.
+func deferExample(t *calltrace.Tracer) {
+	defer t.Enter("deferExample")()
+	a := 10
+	defer func(val int) {
+		defer t.Enter("first deferred func", "val", val)()
+	}(a)
+	t.Event("return", "a", a, "ch", make(chan int))
+}
+
+func TestWriteTree(t *testing.T) {
+	tr := calltrace.New(fakeClock())
+	func() {
+		defer tr.Enter("outer", "n", 1, "odd")()
+		tr.Event("tick")
+		// Not exited: the exit of outer pops it too
+		tr.Enter("inner")
+	}()
+	tr.Event("done", "ok", true)
+
+	var sb strings.Builder
+	if err := tr.WriteTree(&sb); err != nil {
+		t.Fatal(err)
+	}
+	want := "> outer n=1 odd=<nil> (3ms)\n" +
+		"  * tick\n" +
+		"  > inner (0s)\n" +
+		"* done ok=true\n"
+	if sb.String() != want {
+		t.Errorf("WriteTree:\n%s\nwant:\n%s", sb.String(), want)
+	}
+	if spans := tr.Spans(); len(spans) != 2 || len(spans[0].Children) != 2 || !spans[1].Instant {
+		t.Errorf("Spans = %v", spans)
+	}
+}
+
+func TestWriteChromeJSON(t *testing.T) {
+	tr := calltrace.New(fakeClock())
+	deferExample(tr)
+	var sb strings.Builder
+	if err := tr.WriteChromeJSON(&sb); err != nil {
+		t.Fatal(err)
+	}
+
+	type event struct {
+		Name  string         `json:"name"`
+		Phase string         `json:"ph"`
+		TS    float64        `json:"ts"`
+		Dur   *float64       `json:"dur"`
+		Scope string         `json:"s"`
+		PID   int            `json:"pid"`
+		TID   int            `json:"tid"`
+		Args  map[string]any `json:"args"`
+	}
+	var trace struct {
+		TraceEvents     []event `json:"traceEvents"`
+		DisplayTimeUnit string  `json:"displayTimeUnit"`
+	}
+	dec := json.NewDecoder(strings.NewReader(sb.String()))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&trace); err != nil {
+		t.Fatalf("decode: %v\n%s", err, sb.String())
+	}
+	if trace.DisplayTimeUnit != "ms" || len(trace.TraceEvents) != 3 {
+		t.Fatalf("trace = %+v", trace)
+	}
+	// The clock reads: 1 New, 2 Enter, 3 Event, 4 Enter of the deferred func, 5 its exit, 6 exit
+	want := []struct {
+		name, phase string
+		ts, dur     float64
+	}{
+		{"deferExample", "X", 1000, 4000},
+		{"return", "i", 2000, 0},
+		{"first deferred func", "X", 3000, 1000},
+	}
+	for i, ev := range trace.TraceEvents {
+		w := want[i]
+		if ev.Name != w.name || ev.Phase != w.phase || ev.TS != w.ts || ev.PID != 1 || ev.TID != 1 {
+			t.Errorf("event %d = %+v, want %s %s at %v", i, ev, w.name, w.phase, w.ts)
+		}
+		switch {
+		case ev.Phase == "X" && (ev.Dur == nil || *ev.Dur != w.dur):
+			t.Errorf("event %d: dur = %v, want %v", i, ev.Dur, w.dur)
+		case ev.Phase == "i" && (ev.Dur != nil || ev.Scope != "t"):
+			t.Errorf("instant event %d: dur = %v, scope %q", i, ev.Dur, ev.Scope)
+		}
+	}
+	if args := trace.TraceEvents[2].Args; args["val"] != 10.0 {
+		t.Errorf("args of the deferred func = %v", args)
+	}
+	// A channel cannot be encoded: it is stored as text
+	args := trace.TraceEvents[1].Args
+	if s, ok := args["ch"].(string); args["a"] != 10.0 || !ok || !strings.HasPrefix(s, "0x") {
+		t.Errorf("args of the event = %v", args)
+	}
+	if trace.TraceEvents[0].Args != nil {
+		t.Errorf("args of a call without arguments = %v", trace.TraceEvents[0].Args)
+	}
+}
@@ -0,0 +1,183 @@
+// Package checked provides integer arithmetic that detects overflow.
+//
+// Go integer operations wrap around silently: int8(127) + 1 == -128.
+// For every integer type, this package offers three flavors of each operation:
+//
+//	Add, Sub, Mul, Div, Sum                      Return ErrOverflow instead of wrapping
+//	SaturatingAdd, SaturatingSub, ...            Clamp to the minimum or maximum of the type
+//	WrappingAdd, WrappingSub, ...                Wrap around, like the built-in operators
+package checked
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// Every integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Returned when the exact result does not fit in the type.
+var ErrOverflow = errors.New("integer overflow")
+
+// Returned when dividing by 0.
+var ErrDivideByZero = errors.New("cannot divide by 0")
+
+// Report whether T is a signed integer type.
+func signed[T Integer]() bool {
+	var zero T
+	return ^zero < 0
+}
+
+// Return the smallest value of T.
+func MinOf[T Integer]() T {
+	if !signed[T]() {
+		return 0
+	}
+	var zero T
+	bits := unsafe.Sizeof(zero) * 8
+	return T(1) << (bits - 1)
+}
+
+// Return the largest value of T.
+func MaxOf[T Integer]() T {
+	return ^MinOf[T]()
+}
+
+// Example of Checked Operations
+// -----------------------------
+
+// Return a + b, or ErrOverflow if it does not fit in T.
+func Add[T Integer](a, b T) (T, error) {
+	c := a + b
+	if (b > 0 && c < a) || (b < 0 && c > a) {
+		return c, ErrOverflow
+	}
+	return c, nil
+}
+
+// Return a - b, or ErrOverflow if it does not fit in T.
+func Sub[T Integer](a, b T) (T, error) {
+	c := a - b
+	if (b > 0 && c > a) || (b < 0 && c < a) {
+		return c, ErrOverflow
+	}
+	return c, nil
+}
+
+// Return a * b, or ErrOverflow if it does not fit in T.
+func Mul[T Integer](a, b T) (T, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	c := a * b
+	if signed[T]() {
+		// MinOf / -1 overflows itself, so check this case before dividing
+		minT := MinOf[T]()
+		if (a == ^T(0) && b == minT) || (b == ^T(0) && a == minT) {
+			return c, ErrOverflow
+		}
+	}
+	if c/b != a {
+		return c, ErrOverflow
+	}
+	return c, nil
+}
+
+// Return a / b, truncated toward zero.
+// Returns ErrDivideByZero if b is 0, or ErrOverflow for MinOf / -1.
+func Div[T Integer](a, b T) (T, error) {
+	if b == 0 {
+		return 0, ErrDivideByZero
+	}
+	if signed[T]() && a == MinOf[T]() && b == ^T(0) {
+		return a, ErrOverflow
+	}
+	return a / b, nil
+}
+
+// Add any number of integers and return their sum, or ErrOverflow.
+// Stops at the first overflow and returns the partial sum computed so far.
+func Sum[T Integer](nums ...T) (T, error) {
+	var res T
+	for _, n := range nums {
+		next, err := Add(res, n)
+		if err != nil {
+			return res, err
+		}
+		res = next
+	}
+	return res, nil
+}
+
+// Example of Saturating Operations
+// --------------------------------
+
+// Return a + b, clamped to the range of T.
+func SaturatingAdd[T Integer](a, b T) T {
+	c, err := Add(a, b)
+	if err == nil {
+		return c
+	}
+	if b > 0 {
+		return MaxOf[T]()
+	}
+	return MinOf[T]()
+}
+
+// Return a - b, clamped to the range of T.
+func SaturatingSub[T Integer](a, b T) T {
+	c, err := Sub(a, b)
+	if err == nil {
+		return c
+	}
+	if b < 0 {
+		return MaxOf[T]()
+	}
+	return MinOf[T]()
+}
+
+// Return a * b, clamped to the range of T.
+func SaturatingMul[T Integer](a, b T) T {
+	c, err := Mul(a, b)
+	if err == nil {
+		return c
+	}
+	// The exact result is positive when both operands have the same sign
+	if (a < 0) == (b < 0) {
+		return MaxOf[T]()
+	}
+	return MinOf[T]()
+}
+
+// Return a / b, clamped to the range of T.
+// Like the built-in operator, it panics if b is 0.
+func SaturatingDiv[T Integer](a, b T) T {
+	c, err := Div(a, b)
+	switch err {
+	case nil:
+		return c
+	case ErrOverflow:
+		return MaxOf[T]()
+	default:
+		panic(err)
+	}
+}
+
+// Example of Wrapping Operations
+// ------------------------------
+
+// Return a + b, wrapping around on overflow.
+func WrappingAdd[T Integer](a, b T) T { return a + b }
+
+// Return a - b, wrapping around on overflow.
+func WrappingSub[T Integer](a, b T) T { return a - b }
+
+// Return a * b, wrapping around on overflow.
+func WrappingMul[T Integer](a, b T) T { return a * b }
+
+// Return a / b, wrapping around on overflow: MinOf / -1 == MinOf.
+// Like the built-in operator, it panics if b is 0.
+func WrappingDiv[T Integer](a, b T) T { return a / b }
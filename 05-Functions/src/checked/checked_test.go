@@ -0,0 +1,175 @@
+package checked_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"unsafe"
+
+	"github.com/maevadevs/Go-Learning/Functions/src/checked"
+)
+
+// Convert any integer to a big.Int.
+func toBig[T checked.Integer](v T) *big.Int {
+	if checked.MinOf[T]() < 0 {
+		return big.NewInt(int64(v))
+	}
+	return new(big.Int).SetUint64(uint64(v))
+}
+
+// Convert a big.Int that fits in T back to T.
+func fromBig[T checked.Integer](b *big.Int) T {
+	if checked.MinOf[T]() < 0 {
+		return T(b.Int64())
+	}
+	return T(b.Uint64())
+}
+
+// Reduce b modulo 2^bits into the range of T, like the hardware does.
+func wrap[T checked.Integer](b *big.Int) T {
+	var zero T
+	bits := uint(unsafe.Sizeof(zero) * 8)
+	mod := new(big.Int).Lsh(big.NewInt(1), bits)
+	r := new(big.Int).Mod(b, mod)
+	if checked.MinOf[T]() < 0 && r.Cmp(toBig(checked.MaxOf[T]())) > 0 {
+		r.Sub(r, mod)
+	}
+	return fromBig[T](r)
+}
+
+// Clamp b to the range of T.
+func clamp[T checked.Integer](b *big.Int) T {
+	switch {
+	case b.Cmp(toBig(checked.MinOf[T]())) < 0:
+		return checked.MinOf[T]()
+	case b.Cmp(toBig(checked.MaxOf[T]())) > 0:
+		return checked.MaxOf[T]()
+	}
+	return fromBig[T](b)
+}
+
+// Report whether b fits in T.
+func fits[T checked.Integer](b *big.Int) bool {
+	return b.Cmp(toBig(checked.MinOf[T]())) >= 0 && b.Cmp(toBig(checked.MaxOf[T]())) <= 0
+}
+
+// Report whether f panics.
+func panics(f func()) (panicked bool) {
+	defer func() { panicked = recover() != nil }()
+	f()
+	return false
+}
+
+// The values where overflow starts: both ends of the range and their neighbors.
+func boundaries[T checked.Integer]() []T {
+	lo, hi := checked.MinOf[T](), checked.MaxOf[T]()
+	vals := []T{lo, lo + 1, lo + 2, 0, 1, 2, hi / 2, hi/2 + 1, hi - 1, hi}
+	if lo < 0 {
+		vals = append(vals, ^T(0), ^T(0)-1, lo/2, lo/2-1)
+	}
+	return vals
+}
+
+// Check every operation on every pair of boundary values against math/big.
+func testBoundaries[T checked.Integer](t *testing.T) {
+	t.Helper()
+	ops := []struct {
+		name  string
+		ref   func(z, a, b *big.Int) *big.Int
+		check func(a, b T) (T, error)
+		sat   func(a, b T) T
+		wrap  func(a, b T) T
+	}{
+		{"Add", (*big.Int).Add, checked.Add[T], checked.SaturatingAdd[T], checked.WrappingAdd[T]},
+		{"Sub", (*big.Int).Sub, checked.Sub[T], checked.SaturatingSub[T], checked.WrappingSub[T]},
+		{"Mul", (*big.Int).Mul, checked.Mul[T], checked.SaturatingMul[T], checked.WrappingMul[T]},
+		// Quo truncates toward zero like Go
+		{"Div", (*big.Int).Quo, checked.Div[T], checked.SaturatingDiv[T], checked.WrappingDiv[T]},
+	}
+	vals := boundaries[T]()
+	for _, op := range ops {
+		for _, a := range vals {
+			for _, b := range vals {
+				if op.name == "Div" && b == 0 {
+					continue
+				}
+				want := op.ref(new(big.Int), toBig(a), toBig(b))
+
+				got, err := op.check(a, b)
+				switch {
+				case fits[T](want) && (err != nil || got != fromBig[T](want)):
+					t.Errorf("%s(%d, %d) = %d, %v, want %s", op.name, a, b, got, err, want)
+				case !fits[T](want) && !errors.Is(err, checked.ErrOverflow):
+					t.Errorf("%s(%d, %d) = %d, %v, want ErrOverflow", op.name, a, b, got, err)
+				}
+				if got, want := op.sat(a, b), clamp[T](want); got != want {
+					t.Errorf("Saturating%s(%d, %d) = %d, want %d", op.name, a, b, got, want)
+				}
+				if got, want := op.wrap(a, b), wrap[T](want); got != want {
+					t.Errorf("Wrapping%s(%d, %d) = %d, want %d", op.name, a, b, got, want)
+				}
+			}
+		}
+	}
+
+	for _, a := range vals {
+		if _, err := checked.Div(a, 0); !errors.Is(err, checked.ErrDivideByZero) {
+			t.Errorf("Div(%d, 0): err = %v, want ErrDivideByZero", a, err)
+		}
+		if !panics(func() { checked.SaturatingDiv(a, 0) }) {
+			t.Errorf("SaturatingDiv(%d, 0) did not panic", a)
+		}
+		if !panics(func() { checked.WrappingDiv(a, 0) }) {
+			t.Errorf("WrappingDiv(%d, 0) did not panic", a)
+		}
+	}
+}
+
+func TestBoundaries(t *testing.T) {
+	t.Run("int", testBoundaries[int])
+	t.Run("int8", testBoundaries[int8])
+	t.Run("int16", testBoundaries[int16])
+	t.Run("int32", testBoundaries[int32])
+	t.Run("int64", testBoundaries[int64])
+	t.Run("uint", testBoundaries[uint])
+	t.Run("uint8", testBoundaries[uint8])
+	t.Run("uint16", testBoundaries[uint16])
+	t.Run("uint32", testBoundaries[uint32])
+	t.Run("uint64", testBoundaries[uint64])
+	t.Run("uintptr", testBoundaries[uintptr])
+}
+
+func TestMinMaxOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max *big.Int
+		want     string
+	}{
+		{"int8", toBig(checked.MinOf[int8]()), toBig(checked.MaxOf[int8]()), "-128 127"},
+		{"int16", toBig(checked.MinOf[int16]()), toBig(checked.MaxOf[int16]()), "-32768 32767"},
+		{"int32", toBig(checked.MinOf[int32]()), toBig(checked.MaxOf[int32]()), "-2147483648 2147483647"},
+		{"int64", toBig(checked.MinOf[int64]()), toBig(checked.MaxOf[int64]()), "-9223372036854775808 9223372036854775807"},
+		{"uint8", toBig(checked.MinOf[uint8]()), toBig(checked.MaxOf[uint8]()), "0 255"},
+		{"uint16", toBig(checked.MinOf[uint16]()), toBig(checked.MaxOf[uint16]()), "0 65535"},
+		{"uint32", toBig(checked.MinOf[uint32]()), toBig(checked.MaxOf[uint32]()), "0 4294967295"},
+		{"uint64", toBig(checked.MinOf[uint64]()), toBig(checked.MaxOf[uint64]()), "0 18446744073709551615"},
+	}
+	for _, tt := range tests {
+		if got := tt.min.String() + " " + tt.max.String(); got != tt.want {
+			t.Errorf("%s: MinOf MaxOf = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSum(t *testing.T) {
+	if got, err := checked.Sum[int8](100, 27); got != 127 || err != nil {
+		t.Errorf("Sum(100, 27) = %d, %v, want 127", got, err)
+	}
+	// Stops before the overflowing addend
+	if got, err := checked.Sum[int8](100, 27, 1, -50); got != 127 || !errors.Is(err, checked.ErrOverflow) {
+		t.Errorf("Sum(100, 27, 1, -50) = %d, %v, want 127, ErrOverflow", got, err)
+	}
+	if got, err := checked.Sum[uint8](); got != 0 || err != nil {
+		t.Errorf("Sum() = %d, %v, want 0", got, err)
+	}
+}
@@ -0,0 +1,120 @@
+// Package cleanup collects cleanup functions and runs them in reverse order,
+// like defer, but across function boundaries.
+//
+// A constructor that acquires several resources can push a cleanup for each one,
+// roll them all back if a later step fails, or hand the whole stack to its caller.
+package cleanup
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// A stack of cleanup functions. Safe for concurrent use.
+// The zero value is ready to use.
+type Closer struct {
+	mu  sync.Mutex
+	fns []func() error
+}
+
+// Push a cleanup that can fail.
+func (c *Closer) Add(fn func() error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fns = append(c.fns, fn)
+}
+
+// Push a cleanup that cannot fail.
+func (c *Closer) AddFunc(fn func()) {
+	c.Add(func() error {
+		fn()
+		return nil
+	})
+}
+
+// Push the Close method of a resource, such as an *os.File.
+func (c *Closer) AddCloser(cl io.Closer) {
+	c.Add(cl.Close)
+}
+
+// Return the number of pending cleanups.
+func (c *Closer) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.fns)
+}
+
+// Run every cleanup in reverse order and empty the stack.
+// Every cleanup runs even if a previous one failed or panicked.
+// All errors are joined with errors.Join. A panic becomes an error.
+// Calling Close again returns nil.
+func (c *Closer) Close() error {
+	c.mu.Lock()
+	fns := c.fns
+	c.fns = nil
+	c.mu.Unlock()
+
+	var errs []error
+	for i := len(fns) - 1; i >= 0; i-- {
+		if err := runSafe(fns[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Call fn and turn a panic into an error.
+func runSafe(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("cleanup panicked: %v", r)
+		}
+	}()
+	return fn()
+}
+
+// Close the stack if *errp is not nil or if the caller is panicking.
+// Must be called directly with defer, so that it can see the panic:
+//
+//	func open() (res *Resource, err error) {
+//		var c cleanup.Closer
+//		defer c.CloseOnError(&err)
+//		...
+//	}
+//
+// Cleanup errors are joined to *errp. A panic is re-raised after the cleanups ran.
+func (c *Closer) CloseOnError(errp *error) {
+	r := recover()
+	if r == nil && *errp == nil {
+		return
+	}
+	if cerr := c.Close(); cerr != nil {
+		*errp = errors.Join(*errp, cerr)
+	}
+	if r != nil {
+		panic(r)
+	}
+}
+
+// Run the setup steps in order, each one pushing its own cleanups onto the stack.
+// If a step fails, the cleanups pushed so far are rolled back
+// and the error is returned with any cleanup errors joined to it.
+// If a step panics, they are rolled back too, then the panic goes on, like with CloseOnError.
+// On success, the caller owns the stack and must Close it.
+func Build(steps ...func(*Closer) error) (c *Closer, err error) {
+	c = &Closer{}
+	defer func() {
+		if err != nil {
+			c = nil
+		}
+	}()
+	defer c.CloseOnError(&err)
+	for _, step := range steps {
+		if err := step(c); err != nil {
+			return c, err
+		}
+	}
+	return c, nil
+}
@@ -0,0 +1,197 @@
+package cleanup_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/maevadevs/Go-Learning/Functions/src/cleanup"
+)
+
+var errClose = errors.New("close failed")
+
+// A resource that records when it is closed.
+type resource struct {
+	name string
+	log  *[]string
+	err  error
+}
+
+func (r *resource) Close() error {
+	*r.log = append(*r.log, r.name)
+	return r.err
+}
+
+func TestClose(t *testing.T) {
+	tests := []struct {
+		name    string
+		push    func(c *cleanup.Closer, log *[]string)
+		order   []string
+		wantErr []string
+	}{
+		{"empty", func(*cleanup.Closer, *[]string) {}, nil, nil},
+		{"reverse order", func(c *cleanup.Closer, log *[]string) {
+			c.AddCloser(&resource{"file", log, nil})
+			c.AddFunc(func() { *log = append(*log, "func") })
+			c.Add(func() error { *log = append(*log, "add"); return nil })
+		}, []string{"add", "func", "file"}, nil},
+		{"every cleanup runs after a failure", func(c *cleanup.Closer, log *[]string) {
+			c.AddCloser(&resource{"a", log, nil})
+			c.AddCloser(&resource{"b", log, errClose})
+			c.AddCloser(&resource{"c", log, nil})
+		}, []string{"c", "b", "a"}, []string{"close failed"}},
+		{"a panic becomes an error", func(c *cleanup.Closer, log *[]string) {
+			c.AddCloser(&resource{"a", log, errClose})
+			c.AddFunc(func() { panic("oops") })
+			c.AddCloser(&resource{"c", log, nil})
+		}, []string{"c", "a"}, []string{"cleanup panicked: oops", "close failed"}},
+	}
+	for _, tt := range tests {
+		var c cleanup.Closer
+		var log []string
+		tt.push(&c, &log)
+		err := c.Close()
+		if !slices.Equal(log, tt.order) {
+			t.Errorf("%s: closed %v, want %v", tt.name, log, tt.order)
+		}
+		if got := errLines(err); !slices.Equal(got, tt.wantErr) {
+			t.Errorf("%s: err = %q, want %q", tt.name, got, tt.wantErr)
+		}
+		if c.Len() != 0 || c.Close() != nil {
+			t.Errorf("%s: a second Close ran cleanups again", tt.name)
+		}
+	}
+}
+
+// Split the message of a joined error into lines, nil for a nil error.
+func errLines(err error) []string {
+	if err == nil {
+		return nil
+	}
+	return strings.Split(err.Error(), "\n")
+}
+
+// Open two resources and fail as told, like a constructor.
+func open(log *[]string, fail error, panicking bool) (err error) {
+	var c cleanup.Closer
+	defer c.CloseOnError(&err)
+	c.AddCloser(&resource{"a", log, nil})
+	c.AddCloser(&resource{"b", log, errClose})
+	if panicking {
+		panic("setup")
+	}
+	return fail
+}
+
+func TestCloseOnError(t *testing.T) {
+	var log []string
+	if err := open(&log, nil, false); err != nil || len(log) != 0 {
+		t.Errorf("success: err = %v, closed %v", err, log)
+	}
+
+	errSetup := errors.New("setup failed")
+	err := open(&log, errSetup, false)
+	if !errors.Is(err, errSetup) || !errors.Is(err, errClose) || !slices.Equal(log, []string{"b", "a"}) {
+		t.Errorf("failure: err = %v, closed %v", err, log)
+	}
+
+	log = nil
+	func() {
+		defer func() {
+			if r := recover(); r != "setup" {
+				t.Errorf("recovered %v, want the panic of the setup", r)
+			}
+		}()
+		open(&log, nil, true)
+	}()
+	if !slices.Equal(log, []string{"b", "a"}) {
+		t.Errorf("panic: closed %v before the panic went on", log)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	errStep := errors.New("step failed")
+	step := func(name string, log *[]string, err error) func(*cleanup.Closer) error {
+		return func(c *cleanup.Closer) error {
+			if err != nil {
+				return err
+			}
+			c.AddCloser(&resource{name, log, nil})
+			return nil
+		}
+	}
+	tests := []struct {
+		name    string
+		steps   func(log *[]string) []func(*cleanup.Closer) error
+		wantErr string
+		closed  []string
+	}{
+		{"success", func(log *[]string) []func(*cleanup.Closer) error {
+			return []func(*cleanup.Closer) error{step("a", log, nil), step("b", log, nil)}
+		}, "", nil},
+		{"rollback", func(log *[]string) []func(*cleanup.Closer) error {
+			return []func(*cleanup.Closer) error{step("a", log, nil), step("b", log, nil), step("c", log, errStep)}
+		}, "step failed", []string{"b", "a"}},
+	}
+	for _, tt := range tests {
+		var log []string
+		c, err := cleanup.Build(tt.steps(&log)...)
+		if tt.wantErr == "" {
+			if err != nil || c == nil || c.Len() != 2 {
+				t.Fatalf("%s: Build = %v, %v", tt.name, c, err)
+			}
+			c.Close()
+			if !slices.Equal(log, []string{"b", "a"}) {
+				t.Errorf("%s: the caller closed %v", tt.name, log)
+			}
+			continue
+		}
+		if c != nil || err == nil || err.Error() != tt.wantErr || !slices.Equal(log, tt.closed) {
+			t.Errorf("%s: Build = %v, %v, closed %v, want nil, %s, %v", tt.name, c, err, log, tt.wantErr, tt.closed)
+		}
+	}
+}
+
+func TestBuildPanic(t *testing.T) {
+	var log []string
+	steps := []func(*cleanup.Closer) error{
+		func(c *cleanup.Closer) error { c.AddCloser(&resource{"a", &log, errClose}); return nil },
+		func(*cleanup.Closer) error { panic("bad step") },
+	}
+	func() {
+		defer func() {
+			if r := recover(); r != "bad step" {
+				t.Errorf("recovered %v, want the panic of the step", r)
+			}
+		}()
+		cleanup.Build(steps...)
+		t.Error("Build returned after a panic")
+	}()
+	if !slices.Equal(log, []string{"a"}) {
+		t.Errorf("closed %v before the panic went on, want a", log)
+	}
+}
+
+func TestConcurrentAdd(t *testing.T) {
+	var c cleanup.Closer
+	var mu sync.Mutex
+	count := 0
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			for range 100 {
+				c.AddFunc(func() {
+					mu.Lock()
+					count++
+					mu.Unlock()
+				})
+			}
+		})
+	}
+	wg.Wait()
+	if err := c.Close(); err != nil || count != 1000 {
+		t.Errorf("Close = %v after %d cleanups, want 1000", err, count)
+	}
+}
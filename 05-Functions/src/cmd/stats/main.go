@@ -0,0 +1,95 @@
+// Command stats reads numbers from stdin and prints descriptive statistics.
+//
+//	echo 1 2 3 4 5 | go run ./src/cmd/stats -buckets 5
+//
+// With -stream, only running statistics are computed, in constant memory.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/maevadevs/Go-Learning/Functions/src/stats"
+)
+
+// This is the main entry of the application.
+func main() {
+	buckets := flag.Int("buckets", 10, "number of histogram buckets")
+	stream := flag.Bool("stream", false, "compute running statistics only, without storing the values")
+	flag.Parse()
+
+	if err := run(os.Stdin, os.Stdout, *buckets, *stream); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Read whitespace-separated numbers from r and write their statistics to w.
+func run(r io.Reader, w io.Writer, buckets int, stream bool) error {
+	var acc stats.Accumulator
+	var nums []float64
+
+	sc := bufio.NewScanner(r)
+	sc.Split(bufio.ScanWords)
+	for sc.Scan() {
+		x, err := strconv.ParseFloat(sc.Text(), 64)
+		if err != nil {
+			return err
+		}
+		// ParseFloat accepts NaN and Inf, which make every statistic meaningless
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return fmt.Errorf("not a finite number: %q", sc.Text())
+		}
+		acc.Add(x)
+		if !stream {
+			nums = append(nums, x)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+
+	// Running statistics are always available
+	mean, err := acc.Mean()
+	if err != nil {
+		return err
+	}
+	stdDev, _ := acc.StdDev()
+	lo, hi, _ := acc.MinMax()
+	fmt.Fprintln(w, "Count:\t", acc.Count())
+	fmt.Fprintln(w, "Mean:\t", mean)
+	fmt.Fprintln(w, "StdDev:\t", stdDev)
+	fmt.Fprintln(w, "Min:\t", lo)
+	fmt.Fprintln(w, "Max:\t", hi)
+	if stream {
+		return nil
+	}
+
+	// These need all the values
+	median, _ := stats.Median(nums...)
+	modes, _ := stats.Mode(nums...)
+	hist, err := stats.Histogram(buckets, nums...)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(w, "Sum:\t", stats.Sum(nums...))
+	fmt.Fprintln(w, "Median:\t", median)
+	fmt.Fprintln(w, "Mode:\t", modes)
+	fmt.Fprintln(w, "Histogram:")
+	// Scale the bars so that the largest one is at most 50 characters wide
+	largest := 0
+	for _, b := range hist {
+		largest = max(largest, b.Count)
+	}
+	for _, b := range hist {
+		bar := strings.Repeat("#", b.Count*50/max(largest, 50))
+		fmt.Fprintf(w, "\t[%8.3f, %8.3f]\t%5d\t%s\n", b.Lo, b.Hi, b.Count, bar)
+	}
+	return nil
+}
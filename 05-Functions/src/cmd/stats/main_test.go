@@ -0,0 +1,18 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRunRejectsNonFinite(t *testing.T) {
+	for _, in := range []string{"1 2 +Inf", "NaN", "1 -inf 3"} {
+		if err := run(strings.NewReader(in), io.Discard, 3, false); err == nil {
+			t.Errorf("run(%q): no error", in)
+		}
+	}
+	if err := run(strings.NewReader("1 2 3 3"), io.Discard, 3, false); err != nil {
+		t.Errorf("run of finite numbers: %v", err)
+	}
+}
@@ -0,0 +1,296 @@
+// Package config fills a struct from layered sources.
+//
+// Sources are applied in this order, each one overriding the previous:
+//
+//  1. `default` struct tags
+//  2. Config files, in the order given (JSON if the name ends in .json, else TOML-like key = value)
+//  3. Environment variables: EnvPrefix + upper-cased key, e.g. APP_FIRST_NAME
+//  4. Command-line flags: the key with '_' replaced by '-', e.g. --first-name
+//
+// The key of a field is its `config` tag, or its name in snake case if the tag is missing.
+// A field tagged `config:"-"` is ignored.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	"github.com/maevadevs/Go-Learning/Functions/src/validate"
+)
+
+// Where a value came from.
+type Source int
+
+const (
+	SourceNone Source = iota
+	SourceDefault
+	SourceFile
+	SourceEnv
+	SourceFlag
+)
+
+// Print the name of the source.
+func (s Source) String() string {
+	switch s {
+	case SourceDefault:
+		return "default"
+	case SourceFile:
+		return "file"
+	case SourceEnv:
+		return "env"
+	case SourceFlag:
+		return "flag"
+	default:
+		return "unset"
+	}
+}
+
+// Returned when Load is not given a pointer to a struct.
+var ErrNotStruct = errors.New("config: value is not a pointer to a struct")
+
+// Load configuration into a struct.
+type Loader struct {
+	// Prefix of the environment variables, e.g. "APP_".
+	EnvPrefix string
+	// Config files to read. More can be given on the command line with --config.
+	Files []string
+	// Command-line arguments, without the program name. Nil means no flags.
+	Args []string
+	// Look up an environment variable. Nil means os.LookupEnv.
+	LookupEnv func(string) (string, bool)
+	// Where --print-config writes. Nil means os.Stdout.
+	Output io.Writer
+}
+
+// The final value of a field and where it came from.
+type Entry struct {
+	Field  string
+	Key    string
+	Value  string
+	Source Source
+	// The file name, variable name or flag name that set the value.
+	Origin string
+}
+
+// The result of Load, one entry per field in declaration order.
+type Report struct {
+	Entries []Entry
+	// Whether --print-config was given.
+	PrintConfig bool
+	// Arguments left after the flags.
+	Args []string
+}
+
+// Write the report as a table.
+func (r *Report) Print(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "FIELD\tKEY\tVALUE\tSOURCE\tORIGIN")
+	for _, e := range r.Entries {
+		fmt.Fprintf(tw, "%s\t%s\t%q\t%s\t%s\n", e.Field, e.Key, e.Value, e.Source, e.Origin)
+	}
+	return tw.Flush()
+}
+
+// A field of the target struct.
+type field struct {
+	value reflect.Value
+	entry *Entry
+}
+
+// The fields of the target struct by key, in declaration order, and the report being filled.
+type target struct {
+	report   *Report
+	keys     []string
+	defaults map[string]string
+	fields   map[string]field
+}
+
+// Fill the struct pointed to by ptr from all the sources.
+// If --print-config is given, the report is also written to l.Output.
+func (l *Loader) Load(ptr any) (*Report, error) {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return nil, ErrNotStruct
+	}
+	t := newTarget(rv.Elem())
+	report := t.report
+
+	// 1. Defaults
+	for _, key := range t.keys {
+		if def, ok := t.defaults[key]; ok {
+			if err := t.set(key, def, SourceDefault, "tag"); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Parse flags now to find extra config files, but apply them last
+	fs, extraFiles, flagKeys := t.flagSet()
+	if err := fs.Parse(l.Args); err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	report.Args = fs.Args()
+
+	// 2. Files
+	if err := t.applyFiles(append(append([]string{}, l.Files...), *extraFiles...)); err != nil {
+		return nil, err
+	}
+
+	// 3. Environment
+	if err := t.applyEnv(l.EnvPrefix, l.LookupEnv); err != nil {
+		return nil, err
+	}
+
+	// 4. Flags: only those actually given
+	var flagErr error
+	fs.Visit(func(fl *flag.Flag) {
+		if key, ok := flagKeys[fl.Name]; ok && flagErr == nil {
+			flagErr = t.set(key, fl.Value.String(), SourceFlag, "--"+fl.Name)
+		}
+	})
+	if flagErr != nil {
+		return nil, flagErr
+	}
+
+	if report.PrintConfig {
+		out := l.Output
+		if out == nil {
+			out = os.Stdout
+		}
+		if err := report.Print(out); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// Collect the exported fields of a struct that are not tagged `config:"-"`.
+func newTarget(rv reflect.Value) *target {
+	rt := rv.Type()
+	t := &target{report: &Report{}, defaults: map[string]string{}, fields: map[string]field{}}
+	for i := range rt.NumField() {
+		sf := rt.Field(i)
+		key := sf.Tag.Get("config")
+		if key == "-" || !sf.IsExported() {
+			continue
+		}
+		if key == "" {
+			key = snakeCase(sf.Name)
+		}
+		if def, ok := sf.Tag.Lookup("default"); ok {
+			t.defaults[key] = def
+		}
+		t.keys = append(t.keys, key)
+		t.report.Entries = append(t.report.Entries, Entry{
+			Field: sf.Name,
+			Key:   key,
+			Value: fmt.Sprint(rv.Field(i).Interface()),
+		})
+	}
+	// Entries do not move anymore: point each field to its entry
+	for i := range t.report.Entries {
+		e := &t.report.Entries[i]
+		t.fields[e.Key] = field{value: rv.FieldByName(e.Field), entry: e}
+	}
+	return t
+}
+
+// Set a field from its text, and record where the value came from.
+func (t *target) set(key, val string, src Source, origin string) error {
+	f := t.fields[key]
+	if err := validate.SetString(f.value, val); err != nil {
+		return fmt.Errorf("config: %s from %s %s: %w", key, src, origin, err)
+	}
+	f.entry.Value, f.entry.Source, f.entry.Origin = val, src, origin
+	return nil
+}
+
+// Return the flags: --config, --print-config and one per key,
+// with the files given by --config and the key of each flag name.
+func (t *target) flagSet() (*flag.FlagSet, *multiFlag, map[string]string) {
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	extraFiles := &multiFlag{}
+	fs.Var(extraFiles, "config", "config file to read, may be repeated")
+	fs.BoolVar(&t.report.PrintConfig, "print-config", false, "print the configuration and where each value came from")
+	flagKeys := map[string]string{}
+	for _, key := range t.keys {
+		name := flagName(key)
+		fs.String(name, "", "value of "+key)
+		flagKeys[name] = key
+	}
+	return fs, extraFiles, flagKeys
+}
+
+// Set the fields from the config files, in order.
+func (t *target) applyFiles(names []string) error {
+	for _, name := range names {
+		values, err := readFile(name)
+		if err != nil {
+			return err
+		}
+		for _, key := range t.keys {
+			if val, ok := values[key]; ok {
+				if err := t.set(key, val, SourceFile, name); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Set the fields from the environment variables. A nil lookup means os.LookupEnv.
+func (t *target) applyEnv(prefix string, lookup func(string) (string, bool)) error {
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+	for _, key := range t.keys {
+		name := prefix + strings.ToUpper(key)
+		if val, ok := lookup(name); ok {
+			if err := t.set(key, val, SourceEnv, name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Convert a Go field name to snake case: FirstName becomes first_name.
+func snakeCase(name string) string {
+	var sb strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a new word, except inside an acronym like ID
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				sb.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// Convert a key to a flag name: first_name becomes first-name.
+func flagName(key string) string {
+	return strings.ReplaceAll(key, "_", "-")
+}
+
+// A flag that can be given several times.
+type multiFlag []string
+
+func (m *multiFlag) String() string { return strings.Join(*m, ",") }
+
+func (m *multiFlag) Set(s string) error {
+	*m = append(*m, s)
+	return nil
+}
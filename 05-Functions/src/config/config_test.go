@@ -0,0 +1,197 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/maevadevs/Go-Learning/Functions/src/config"
+)
+
+type settings struct {
+	Name    string `default:"anon"`
+	Port    int    `config:"port" default:"80"`
+	Debug   bool
+	UserID  string
+	Ignored string `config:"-"`
+	hidden  string
+}
+
+// Write the files in a temporary directory and return their paths.
+func writeFiles(t *testing.T, files map[string]string) map[string]string {
+	t.Helper()
+	dir := t.TempDir()
+	paths := map[string]string{}
+	for name, content := range files {
+		paths[name] = filepath.Join(dir, name)
+		if err := os.WriteFile(paths[name], []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return paths
+}
+
+// Return a lookup function over a fixed environment.
+func env(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		v, ok := vars[name]
+		return v, ok
+	}
+}
+
+func TestPrecedence(t *testing.T) {
+	paths := writeFiles(t, map[string]string{
+		"base.toml":  "# Base\nname = \"file\"\nport = 8080 # trailing comment\ndebug = true\nuser_id = \"u1\"\n",
+		"over.json":  `{"port": 9090, "name": "json"}`,
+		"extra.toml": "user_id = u3\n",
+	})
+	tests := []struct {
+		name   string
+		files  []string
+		env    map[string]string
+		args   []string
+		want   settings
+		source map[string]config.Source
+	}{
+		{"defaults only", nil, nil, nil,
+			settings{Name: "anon", Port: 80},
+			map[string]config.Source{"name": config.SourceDefault, "debug": config.SourceNone}},
+		{"file over defaults", []string{paths["base.toml"]}, nil, nil,
+			settings{Name: "file", Port: 8080, Debug: true, UserID: "u1"},
+			map[string]config.Source{"name": config.SourceFile, "port": config.SourceFile}},
+		{"later file over earlier", []string{paths["base.toml"], paths["over.json"]}, nil, nil,
+			settings{Name: "json", Port: 9090, Debug: true, UserID: "u1"},
+			map[string]config.Source{"port": config.SourceFile}},
+		{"env over files", []string{paths["base.toml"]}, map[string]string{"APP_PORT": "7070", "PORT": "1"}, nil,
+			settings{Name: "file", Port: 7070, Debug: true, UserID: "u1"},
+			map[string]config.Source{"port": config.SourceEnv}},
+		{"flags over env", []string{paths["base.toml"]}, map[string]string{"APP_PORT": "7070", "APP_NAME": "env"}, []string{"--port", "6060"},
+			settings{Name: "env", Port: 6060, Debug: true, UserID: "u1"},
+			map[string]config.Source{"port": config.SourceFlag, "name": config.SourceEnv}},
+		{"--config after the loader files", []string{paths["base.toml"]}, nil, []string{"--config", paths["extra.toml"], "--user-id", "u4"},
+			settings{Name: "file", Port: 8080, Debug: true, UserID: "u4"},
+			map[string]config.Source{"user_id": config.SourceFlag}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got settings
+			l := config.Loader{EnvPrefix: "APP_", Files: tt.files, Args: tt.args, LookupEnv: env(tt.env)}
+			report, err := l.Load(&got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Load = %+v, want %+v", got, tt.want)
+			}
+			for _, e := range report.Entries {
+				if src, ok := tt.source[e.Key]; ok && e.Source != src {
+					t.Errorf("%s from %v (%s), want %v", e.Key, e.Source, e.Origin, src)
+				}
+			}
+		})
+	}
+}
+
+func TestReport(t *testing.T) {
+	var got settings
+	var out strings.Builder
+	l := config.Loader{Args: []string{"--debug", "true", "--print-config", "rest"}, LookupEnv: env(nil), Output: &out}
+	report, err := l.Load(&got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Entries) != 4 || !report.PrintConfig || len(report.Args) != 1 || report.Args[0] != "rest" {
+		t.Errorf("report = %+v", report)
+	}
+	want := "FIELD   KEY      VALUE   SOURCE   ORIGIN\n" +
+		"Name    name     \"anon\"  default  tag\n" +
+		"Port    port     \"80\"    default  tag\n" +
+		"Debug   debug    \"true\"  flag     --debug\n" +
+		"UserID  user_id  \"\"      unset    \n"
+	if out.String() != want {
+		t.Errorf("printed:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestSections(t *testing.T) {
+	paths := writeFiles(t, map[string]string{
+		"sections.toml": "name = \"top\" # a note\n\n[server] # the server\nport = 8080\n\n[client]\nname = \"client\"\n",
+		"bad.toml":      "[server\nport = 1\n",
+		"tables.toml":   "[[server]]\nport = 1\n",
+	})
+	var got settings
+	l := config.Loader{Files: []string{paths["sections.toml"]}, LookupEnv: env(nil)}
+	if _, err := l.Load(&got); err != nil {
+		t.Fatal(err)
+	}
+	// server.port and client.name are not the top-level keys port and name
+	if got.Name != "top" || got.Port != 80 {
+		t.Errorf("Load with sections = %+v, want name top and the default port", got)
+	}
+
+	for _, name := range []string{"bad.toml", "tables.toml"} {
+		l := config.Loader{Files: []string{paths[name]}, LookupEnv: env(nil)}
+		if _, err := l.Load(&got); err == nil || !strings.Contains(err.Error(), "invalid section header") {
+			t.Errorf("Load(%s): err = %v", name, err)
+		}
+	}
+}
+
+func TestQuotedValues(t *testing.T) {
+	tests := []struct {
+		line, want string
+	}{
+		{`user_id = "x"`, "x"},
+		{`user_id = "x" # note`, "x"},
+		{`user_id = "x"# note`, "x"},
+		{`user_id = "a # b" # note`, "a # b"},
+		{`user_id = "say \"hi\"" # note`, `say "hi"`},
+		{`user_id = x # note`, "x"},
+	}
+	for _, tt := range tests {
+		paths := writeFiles(t, map[string]string{"f.toml": tt.line + "\n"})
+		var got settings
+		l := config.Loader{Files: []string{paths["f.toml"]}, LookupEnv: env(nil)}
+		if _, err := l.Load(&got); err != nil || got.UserID != tt.want {
+			t.Errorf("%s: UserID = %q, %v, want %q", tt.line, got.UserID, err, tt.want)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	paths := writeFiles(t, map[string]string{
+		"noequal.toml":  "port 8080\n",
+		"badport.toml":  "port = eighty\n",
+		"trailing.toml": "name = \"x\" y\n",
+		"bad.json":      `{"port": `,
+	})
+	var s settings
+	tests := []struct {
+		name string
+		l    config.Loader
+		ptr  any
+		want string
+	}{
+		{"not a pointer", config.Loader{}, s, "not a pointer to a struct"},
+		{"missing file", config.Loader{Files: []string{"missing.toml"}}, &s, "no such file"},
+		{"no equal sign", config.Loader{Files: []string{paths["noequal.toml"]}}, &s, "noequal.toml:1: expected key = value"},
+		{"bad value in a file", config.Loader{Files: []string{paths["badport.toml"]}}, &s, "port from file"},
+		{"text after a quoted value", config.Loader{Files: []string{paths["trailing.toml"]}}, &s, "trailing.toml:1: unexpected y"},
+		{"bad JSON", config.Loader{Files: []string{paths["bad.json"]}}, &s, "bad.json"},
+		{"bad value in the env", config.Loader{LookupEnv: env(map[string]string{"PORT": "x"})}, &s, "port from env PORT"},
+		{"unknown flag", config.Loader{Args: []string{"--colour"}}, &s, "colour"},
+	}
+	for _, tt := range tests {
+		if tt.l.LookupEnv == nil {
+			tt.l.LookupEnv = env(nil)
+		}
+		if _, err := tt.l.Load(tt.ptr); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: err = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+	if _, err := (&config.Loader{}).Load(&s.Port); !errors.Is(err, config.ErrNotStruct) {
+		t.Errorf("Load(*int): err = %v, want ErrNotStruct", err)
+	}
+}
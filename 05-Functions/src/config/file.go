@@ -0,0 +1,108 @@
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Read a config file into a map of key to raw value.
+func readFile(name string) (map[string]string, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	if strings.HasSuffix(name, ".json") {
+		return parseJSON(name, data)
+	}
+	return parseTOML(name, data)
+}
+
+// Parse a flat JSON object. Values are kept as their text.
+func parseJSON(name string, data []byte) (map[string]string, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("config: %s: %w", name, err)
+	}
+	values := make(map[string]string, len(raw))
+	for k, v := range raw {
+		// Unquote strings, keep numbers and booleans as-is
+		var s string
+		if err := json.Unmarshal(v, &s); err == nil {
+			values[k] = s
+		} else {
+			values[k] = string(v)
+		}
+	}
+	return values, nil
+}
+
+// Parse a TOML-like file: one `key = value` per line.
+// Blank lines and `# comments` are ignored. Values may be double-quoted.
+// Keys after a `[section]` header are prefixed with the section, like in TOML:
+// `port` under `[server]` is the key `server.port`, which does not match a top-level field.
+func parseTOML(name string, data []byte) (map[string]string, error) {
+	values := map[string]string{}
+	section := ""
+	sc := bufio.NewScanner(strings.NewReader(string(data)))
+	for lineNum := 1; sc.Scan(); lineNum++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if line[0] == '[' {
+			var err error
+			if section, err = parseSection(line); err != nil {
+				return nil, fmt.Errorf("config: %s:%d: %w", name, lineNum, err)
+			}
+			continue
+		}
+		key, val, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("config: %s:%d: expected key = value", name, lineNum)
+		}
+		key, val = section+strings.TrimSpace(key), strings.TrimSpace(val)
+		var err error
+		if val, err = parseValue(val); err != nil {
+			return nil, fmt.Errorf("config: %s:%d: %w", name, lineNum, err)
+		}
+		values[key] = val
+	}
+	return values, sc.Err()
+}
+
+// Unquote a double-quoted value and drop a trailing `# comment`.
+// A # inside the quotes is part of the value.
+func parseValue(val string) (string, error) {
+	if !strings.HasPrefix(val, `"`) {
+		if i := strings.Index(val, "#"); i >= 0 {
+			val = strings.TrimSpace(val[:i])
+		}
+		return val, nil
+	}
+	quoted, err := strconv.QuotedPrefix(val)
+	if err != nil {
+		return "", err
+	}
+	if rest := strings.TrimSpace(val[len(quoted):]); rest != "" && rest[0] != '#' {
+		return "", fmt.Errorf("unexpected %s after the quoted value", rest)
+	}
+	return strconv.Unquote(quoted)
+}
+
+// Return the prefix of the keys under a `[section]` header: "section.".
+// Arrays of tables, `[[section]]`, are not supported.
+func parseSection(line string) (string, error) {
+	if i := strings.Index(line, "#"); i >= 0 {
+		line = strings.TrimSpace(line[:i])
+	}
+	name, ok := strings.CutSuffix(line[1:], "]")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" || strings.ContainsAny(name, "[]") {
+		return "", fmt.Errorf("invalid section header %s", line)
+	}
+	return name + ".", nil
+}
@@ -0,0 +1,134 @@
+// Package decorate wraps function values of type func(string) int with
+// reusable behaviors such as logging, timing, panic recovery, caching and metrics.
+package decorate
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// A function that takes a string and returns an int, like f1 and f2.
+type StrIntFunc func(string) int
+
+// A function that wraps a StrIntFunc and returns a new StrIntFunc.
+type Decorator func(StrIntFunc) StrIntFunc
+
+// Wrap a function with decorators.
+// Decorators are applied in declared order: the first one is the outermost.
+func Chain(fn StrIntFunc, decorators ...Decorator) StrIntFunc {
+	for i := len(decorators) - 1; i >= 0; i-- {
+		fn = decorators[i](fn)
+	}
+	return fn
+}
+
+// Example of a Logging Decorator
+// ------------------------------
+
+// Log the argument and the result of every call, prefixed with a name.
+func WithLogging(logger *log.Logger, name string) Decorator {
+	return func(next StrIntFunc) StrIntFunc {
+		return func(s string) int {
+			res := next(s)
+			logger.Printf("%s(%q) = %d", name, s, res)
+			return res
+		}
+	}
+}
+
+// Example of a Timing Decorator
+// -----------------------------
+
+// Report how long every call took.
+func WithTiming(report func(time.Duration)) Decorator {
+	return func(next StrIntFunc) StrIntFunc {
+		return func(s string) int {
+			start := time.Now()
+			// Report even if next panics
+			defer func() {
+				report(time.Since(start))
+			}()
+			return next(s)
+		}
+	}
+}
+
+// Example of a Panic-Recovery Decorator
+// -------------------------------------
+
+// Recover from a panic in the wrapped function and return fallback instead.
+// onPanic is called with the recovered value if it is not nil.
+func WithRecover(fallback int, onPanic func(any)) Decorator {
+	return func(next StrIntFunc) StrIntFunc {
+		return func(s string) (res int) {
+			defer func() {
+				if r := recover(); r != nil {
+					if onPanic != nil {
+						onPanic(r)
+					}
+					res = fallback
+				}
+			}()
+			return next(s)
+		}
+	}
+}
+
+// Example of a Caching Decorator
+// ------------------------------
+
+// Remember the result for each argument so the wrapped function runs once per input.
+// The cache is safe for concurrent use.
+func WithCache() Decorator {
+	return func(next StrIntFunc) StrIntFunc {
+		var mu sync.Mutex
+		cache := map[string]int{}
+		return func(s string) int {
+			mu.Lock()
+			res, ok := cache[s]
+			mu.Unlock()
+			if ok {
+				return res
+			}
+			res = next(s)
+			mu.Lock()
+			cache[s] = res
+			mu.Unlock()
+			return res
+		}
+	}
+}
+
+// Example of a Metrics Decorator
+// ------------------------------
+
+// Counters collected by WithMetrics. Safe for concurrent use.
+type Metrics struct {
+	Calls  atomic.Int64
+	Panics atomic.Int64
+}
+
+// Print the counters.
+func (m *Metrics) String() string {
+	return fmt.Sprintf("calls=%d panics=%d", m.Calls.Load(), m.Panics.Load())
+}
+
+// Count calls and panics of the wrapped function into m.
+// Panics are counted then propagated.
+func WithMetrics(m *Metrics) Decorator {
+	return func(next StrIntFunc) StrIntFunc {
+		return func(s string) int {
+			m.Calls.Add(1)
+			defer func() {
+				if r := recover(); r != nil {
+					m.Panics.Add(1)
+					panic(r)
+				}
+			}()
+			return next(s)
+		}
+	}
+}
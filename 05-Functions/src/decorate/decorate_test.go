@@ -0,0 +1,149 @@
+package decorate_test
+
+import (
+	"bytes"
+	"log"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maevadevs/Go-Learning/Functions/src/decorate"
+)
+
+// Return the length of s, or panic on "boom".
+func length(s string) int {
+	if s == "boom" {
+		panic("boom!")
+	}
+	return len(s)
+}
+
+// Record the order in which decorators run.
+func trace(calls *[]string, name string) decorate.Decorator {
+	return func(next decorate.StrIntFunc) decorate.StrIntFunc {
+		return func(s string) int {
+			*calls = append(*calls, name+" before")
+			res := next(s)
+			*calls = append(*calls, name+" after")
+			return res
+		}
+	}
+}
+
+// Call fn and report whether it panicked.
+func panics(fn func()) (panicked bool) {
+	defer func() { panicked = recover() != nil }()
+	fn()
+	return false
+}
+
+func TestChain(t *testing.T) {
+	var calls []string
+	fn := decorate.Chain(length, trace(&calls, "a"), trace(&calls, "b"))
+	if got := fn("hello"); got != 5 {
+		t.Errorf("Chain(length)(hello) = %d, want 5", got)
+	}
+	want := []string{"a before", "b before", "b after", "a after"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("calls = %v, want %v: the first decorator is the outermost", calls, want)
+	}
+	if got := decorate.Chain(length)("abc"); got != 3 {
+		t.Errorf("Chain without decorators = %d, want 3", got)
+	}
+}
+
+func TestWithLogging(t *testing.T) {
+	var buf bytes.Buffer
+	fn := decorate.Chain(length, decorate.WithLogging(log.New(&buf, "", 0), "length"))
+	fn("hi")
+	fn(`"q"`)
+	if got, want := buf.String(), "length(\"hi\") = 2\nlength(\"\\\"q\\\"\") = 3\n"; got != want {
+		t.Errorf("log = %q, want %q", got, want)
+	}
+}
+
+func TestWithTiming(t *testing.T) {
+	var durations []time.Duration
+	fn := decorate.Chain(func(s string) int {
+		if s == "boom" {
+			panic(s)
+		}
+		time.Sleep(time.Millisecond)
+		return 1
+	}, decorate.WithTiming(func(d time.Duration) { durations = append(durations, d) }))
+	fn("x")
+	if !panics(func() { fn("boom") }) {
+		t.Error("the panic did not go through WithTiming")
+	}
+	if len(durations) != 2 || durations[0] < time.Millisecond {
+		t.Errorf("durations = %v, want 2, the first at least 1ms", durations)
+	}
+}
+
+func TestWithRecover(t *testing.T) {
+	tests := []struct {
+		name      string
+		onPanic   bool
+		input     string
+		want      int
+		recovered []any
+	}{
+		{"no panic", true, "abc", 3, nil},
+		{"panic", true, "boom", -1, []any{"boom!"}},
+		{"panic without onPanic", false, "boom", -1, nil},
+	}
+	for _, tt := range tests {
+		var recovered []any
+		var onPanic func(any)
+		if tt.onPanic {
+			onPanic = func(r any) { recovered = append(recovered, r) }
+		}
+		fn := decorate.Chain(length, decorate.WithRecover(-1, onPanic))
+		if got := fn(tt.input); got != tt.want || !slices.Equal(recovered, tt.recovered) {
+			t.Errorf("%s: got %d, recovered %v, want %d, %v", tt.name, got, recovered, tt.want, tt.recovered)
+		}
+	}
+}
+
+func TestWithCache(t *testing.T) {
+	var calls []string
+	fn := decorate.Chain(func(s string) int {
+		calls = append(calls, s)
+		return len(s)
+	}, decorate.WithCache())
+	for _, s := range []string{"a", "bb", "a", "a", "bb", "ccc"} {
+		if got := fn(s); got != len(s) {
+			t.Errorf("fn(%q) = %d", s, got)
+		}
+	}
+	if want := []string{"a", "bb", "ccc"}; !slices.Equal(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+
+	// Each decorated function has its own cache
+	other := decorate.Chain(func(string) int { return 0 }, decorate.WithCache())
+	if got := other("a"); got != 0 {
+		t.Errorf("second cache: fn(a) = %d, want 0", got)
+	}
+}
+
+func TestWithMetrics(t *testing.T) {
+	var m decorate.Metrics
+	fn := decorate.Chain(length, decorate.WithMetrics(&m))
+	fn("a")
+	fn("b")
+	if !panics(func() { fn("boom") }) {
+		t.Error("WithMetrics swallowed the panic")
+	}
+	if got := m.String(); got != "calls=3 panics=1" {
+		t.Errorf("metrics = %s", got)
+	}
+
+	// Counted inside WithRecover: the panic is counted, then recovered
+	m2 := &decorate.Metrics{}
+	fn = decorate.Chain(length, decorate.WithRecover(0, nil), decorate.WithMetrics(m2))
+	if got := fn("boom"); got != 0 || !strings.HasSuffix(m2.String(), "panics=1") {
+		t.Errorf("fn(boom) = %d, metrics %s", got, m2)
+	}
+}
@@ -0,0 +1,115 @@
+// Package division divides integers with a choice of rounding mode.
+//
+// Go's / and % truncate toward zero, so divmod(-5, 2) gives -2, -1.
+// Other languages and domains expect other conventions.
+// Every mode keeps the invariant q*d + r == n, but rounds q differently,
+// which changes the sign of r:
+//
+//	Mode        q rounds      sign of r          -5, 2     5, -2     -5, -2
+//	Truncated   toward zero   same as n          -2, -1    -2, 1     2, -1
+//	Floored     toward -inf   same as d          -3, 1     -3, -1    2, -1
+//	Euclidean   so r >= 0     always >= 0        -3, 1     -2, 1     3, 1
+//
+// All modes agree when n and d are both positive, and for every unsigned type.
+package division
+
+import (
+	"fmt"
+
+	"github.com/maevadevs/Go-Learning/Functions/src/checked"
+)
+
+// How the quotient is rounded.
+type Mode int
+
+const (
+	// Round toward zero, like Go's / and %.
+	Truncated Mode = iota
+	// Round toward negative infinity, like Python's // and %.
+	Floored
+	// Round so that the remainder is never negative.
+	Euclidean
+)
+
+// All the modes, in declaration order.
+var Modes = []Mode{Truncated, Floored, Euclidean}
+
+// Print the name of the mode.
+func (m Mode) String() string {
+	switch m {
+	case Truncated:
+		return "truncated"
+	case Floored:
+		return "floored"
+	case Euclidean:
+		return "euclidean"
+	default:
+		return fmt.Sprintf("Mode(%d)", int(m))
+	}
+}
+
+// Return the mode with the given name.
+func ParseMode(name string) (Mode, error) {
+	for _, m := range Modes {
+		if m.String() == name {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown division mode %q", name)
+}
+
+// Divide n by d using the given mode and return the quotient and the remainder.
+// Returns checked.ErrDivideByZero if d is 0, or checked.ErrOverflow for MinOf / -1.
+func DivMod[T checked.Integer](n, d T, mode Mode) (q, r T, err error) {
+	switch mode {
+	case Truncated:
+		return TruncDivMod(n, d)
+	case Floored:
+		return FloorDivMod(n, d)
+	case Euclidean:
+		return EuclidDivMod(n, d)
+	default:
+		return 0, 0, fmt.Errorf("unknown division mode %v", mode)
+	}
+}
+
+// Divide n by d, rounding the quotient toward zero.
+func TruncDivMod[T checked.Integer](n, d T) (q, r T, err error) {
+	q, err = checked.Div(n, d)
+	if err != nil {
+		return 0, 0, err
+	}
+	return q, n % d, nil
+}
+
+// Divide n by d, rounding the quotient toward negative infinity.
+func FloorDivMod[T checked.Integer](n, d T) (q, r T, err error) {
+	q, r, err = TruncDivMod(n, d)
+	if err != nil {
+		return 0, 0, err
+	}
+	// Adjust when the remainder and the divisor have opposite signs
+	if r != 0 && (r < 0) != (d < 0) {
+		q--
+		r += d
+	}
+	return q, r, nil
+}
+
+// Divide n by d, rounding the quotient so that the remainder is never negative.
+func EuclidDivMod[T checked.Integer](n, d T) (q, r T, err error) {
+	q, r, err = TruncDivMod(n, d)
+	if err != nil {
+		return 0, 0, err
+	}
+	if r < 0 {
+		if d > 0 {
+			q--
+			r += d
+		} else {
+			q++
+			r -= d
+		}
+	}
+	return q, r, nil
+}
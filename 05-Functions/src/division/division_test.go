@@ -0,0 +1,139 @@
+package division_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"testing/quick"
+
+	"github.com/maevadevs/Go-Learning/Functions/src/checked"
+	"github.com/maevadevs/Go-Learning/Functions/src/division"
+)
+
+// Check the properties of one division in the given mode:
+// q*d + r == n, |r| < |d|, and the sign of r required by the mode.
+func holds[T checked.Integer](n, d T, mode division.Mode) bool {
+	q, r, err := division.DivMod(n, d, mode)
+	switch {
+	case d == 0:
+		return errors.Is(err, checked.ErrDivideByZero)
+	case checked.MinOf[T]() < 0 && n == checked.MinOf[T]() && d == ^T(0):
+		// The quotient -MinOf does not fit: whatever the mode
+		return errors.Is(err, checked.ErrOverflow)
+	case err != nil:
+		return false
+	}
+	// The invariant holds exactly even when q*d overflows: both sides wrap the same way
+	if q*d+r != n || abs(r) >= abs(d) {
+		return false
+	}
+	switch mode {
+	case division.Truncated:
+		// r has the sign of n
+		return r == 0 || (r < 0) == (n < 0)
+	case division.Floored:
+		// r has the sign of d
+		return r == 0 || (r < 0) == (d < 0)
+	case division.Euclidean:
+		return r >= 0
+	}
+	return false
+}
+
+// Return |x|, as an unsigned value so that |MinOf| fits.
+func abs[T checked.Integer](x T) uint64 {
+	if x < 0 {
+		return uint64(-int64(x))
+	}
+	return uint64(x)
+}
+
+func TestPropertiesExhaustive(t *testing.T) {
+	// Every pair of int8 and uint8 is small enough to try them all
+	for _, mode := range division.Modes {
+		for n := math.MinInt8; n <= math.MaxInt8; n++ {
+			for d := math.MinInt8; d <= math.MaxInt8; d++ {
+				if !holds(int8(n), int8(d), mode) {
+					q, r, err := division.DivMod(int8(n), int8(d), mode)
+					t.Fatalf("%v: DivMod(%d, %d) = %d, %d, %v", mode, n, d, q, r, err)
+				}
+			}
+		}
+		for n := range math.MaxUint8 + 1 {
+			for d := range math.MaxUint8 + 1 {
+				if !holds(uint8(n), uint8(d), mode) {
+					q, r, err := division.DivMod(uint8(n), uint8(d), mode)
+					t.Fatalf("%v: DivMod(%d, %d) = %d, %d, %v", mode, n, d, q, r, err)
+				}
+			}
+		}
+	}
+}
+
+func TestPropertiesQuick(t *testing.T) {
+	cfg := &quick.Config{MaxCount: 10000}
+	for _, mode := range division.Modes {
+		t.Run(mode.String(), func(t *testing.T) {
+			if err := quick.Check(func(n, d int64) bool { return holds(n, d, mode) }, cfg); err != nil {
+				t.Error("int64:", err)
+			}
+			if err := quick.Check(func(n, d int32) bool { return holds(n, d, mode) }, cfg); err != nil {
+				t.Error("int32:", err)
+			}
+			// Small divisors hit the boundaries more often than random ones
+			if err := quick.Check(func(n int64, d int8) bool { return holds(n, int64(d), mode) }, cfg); err != nil {
+				t.Error("int64 by int8:", err)
+			}
+			if err := quick.Check(func(n, d uint64) bool { return holds(n, d, mode) }, cfg); err != nil {
+				t.Error("uint64:", err)
+			}
+			for _, n := range []int64{math.MinInt64, math.MinInt64 + 1, -1, 0, 1, math.MaxInt64} {
+				for _, d := range []int64{math.MinInt64, -2, -1, 0, 1, 2, math.MaxInt64} {
+					if !holds(n, d, mode) {
+						t.Errorf("DivMod(%d, %d) breaks the properties", n, d)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDivMod(t *testing.T) {
+	// The table of the package documentation
+	tests := []struct {
+		mode       division.Mode
+		n, d, q, r int
+	}{
+		{division.Truncated, -5, 2, -2, -1},
+		{division.Truncated, 5, -2, -2, 1},
+		{division.Truncated, -5, -2, 2, -1},
+		{division.Floored, -5, 2, -3, 1},
+		{division.Floored, 5, -2, -3, -1},
+		{division.Floored, -5, -2, 2, -1},
+		{division.Euclidean, -5, 2, -3, 1},
+		{division.Euclidean, 5, -2, -2, 1},
+		{division.Euclidean, -5, -2, 3, 1},
+	}
+	for _, tt := range tests {
+		if q, r, err := division.DivMod(tt.n, tt.d, tt.mode); q != tt.q || r != tt.r || err != nil {
+			t.Errorf("%v: DivMod(%d, %d) = %d, %d, %v, want %d, %d", tt.mode, tt.n, tt.d, q, r, err, tt.q, tt.r)
+		}
+	}
+	if _, _, err := division.DivMod(1, 1, division.Mode(9)); err == nil {
+		t.Error("DivMod with an unknown mode: no error")
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for _, mode := range division.Modes {
+		if got, err := division.ParseMode(mode.String()); got != mode || err != nil {
+			t.Errorf("ParseMode(%q) = %v, %v", mode, got, err)
+		}
+	}
+	if _, err := division.ParseMode("rounded"); err == nil {
+		t.Error("ParseMode(rounded): no error")
+	}
+	if got := division.Mode(9).String(); got != "Mode(9)" {
+		t.Errorf("Mode(9).String() = %q", got)
+	}
+}
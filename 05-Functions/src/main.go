@@ -1,14 +1,29 @@
 package main
 
 import (
+	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"log"
+	"math"
 	"os"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/maevadevs/Go-Learning/Functions/src/apperr"
+	"github.com/maevadevs/Go-Learning/Functions/src/calltrace"
+	"github.com/maevadevs/Go-Learning/Functions/src/checked"
+	"github.com/maevadevs/Go-Learning/Functions/src/cleanup"
+	"github.com/maevadevs/Go-Learning/Functions/src/config"
+	"github.com/maevadevs/Go-Learning/Functions/src/decorate"
+	"github.com/maevadevs/Go-Learning/Functions/src/division"
+	"github.com/maevadevs/Go-Learning/Functions/src/params"
+	"github.com/maevadevs/Go-Learning/Functions/src/resilience"
+	"github.com/maevadevs/Go-Learning/Functions/src/stats"
 )
 
 // Example of Call-By-Value
@@ -17,6 +32,10 @@ type Person2 struct{}
 
 // This is the main entry of the application.
 func main() {
+	// Flags: -params <file>, then the file to print with cat
+	paramsFile := flag.String("params", "src/textfiles/params.toml", "config file of the layered parameters example")
+	flag.Parse()
+
 	// Headers
 	fmt.Println()
 	fmt.Println(strings.Repeat("-", 100))
@@ -48,6 +67,48 @@ func main() {
 		FirstName: "Mary",
 		LastName:  "Smith",
 	})
+
+	// Invalid parameters are rejected
+	if err := MyFunc(FuncParams{Age: -1}); err != nil {
+		fmt.Println("MyFunc(FuncParams{Age: -1}) =>", err)
+	}
+	fmt.Println()
+
+	// Example of Functional Options
+	// -----------------------------
+	fmt.Println("Example of Functional Options:")
+	fmt.Println("------------------------------")
+
+	// Unset fields get their default value
+	withOpts, err := params.NewParams(params.WithLastName("Smith"), params.WithAge(50))
+	fmt.Println("NewParams(WithLastName, WithAge) =>", withOpts, err)
+	_, err = params.NewParams(params.WithAge(200))
+	fmt.Println("NewParams(WithAge(200)) =>", err)
+	fmt.Println()
+
+	// Example of Loading Parameters From Layered Sources
+	// --------------------------------------------------
+	fmt.Println("Example of Loading Parameters From Layered Sources:")
+	fmt.Println("---------------------------------------------------")
+
+	// Precedence: default tags < config files < environment < flags
+	var loaded FuncParams
+	loader := config.Loader{
+		EnvPrefix: "APP_",
+		Files:     []string{*paramsFile},
+		Args:      []string{"--age", "30", "--print-config"},
+		LookupEnv: func(name string) (string, bool) {
+			// Simulate APP_FIRST_NAME=Jane in the environment
+			if name == "APP_FIRST_NAME" {
+				return "Jane", true
+			}
+			return "", false
+		},
+	}
+	if _, err := loader.Load(&loaded); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println("Loaded parameters:", loaded)
 	fmt.Println()
 
 	// Example of a Variadic Function
@@ -67,6 +128,29 @@ func main() {
 	fmt.Println("addNums(nums...) =", addNums(nums...))
 	fmt.Println()
 
+	// Example of Variadic Generic Functions
+	// -------------------------------------
+	fmt.Println("Example of Variadic Generic Functions:")
+	fmt.Println("--------------------------------------")
+
+	mean, _ := stats.Mean(nums...)
+	median, _ := stats.Median(1, 3, 3, 7, 10)
+	modes, _ := stats.Mode(1, 3, 3, 7, 10)
+	stdDev, _ := stats.StdDev(nums...)
+	fmt.Println("stats.Mean(nums...) =", mean)
+	fmt.Println("stats.Median(1, 3, 3, 7, 10) =", median)
+	fmt.Println("stats.Mode(1, 3, 3, 7, 10) =", modes)
+	fmt.Println("stats.StdDev(nums...) =", stdDev)
+	// Kahan summation keeps the small values that a naive sum loses
+	floats := []float64{1e16, 1, 1, 1, 1, -1e16}
+	naive := 0.0
+	for _, f := range floats {
+		naive += f
+	}
+	fmt.Println("Naive sum of", floats, "=", naive)
+	fmt.Println("stats.Sum of", floats, "=", stats.Sum(floats...))
+	fmt.Println()
+
 	// Example of Function With Multiple Return Values
 	// -----------------------------------------------
 	fmt.Println("Example of Function With Multiple Return Values:")
@@ -80,6 +164,22 @@ func main() {
 	fmt.Println("divmod(5, 2) =>", "ResDiv =", resDiv, "ResMod =", resMod)
 	fmt.Println()
 
+	// Example of Typed Errors
+	// -----------------------
+	fmt.Println("Example of Typed Errors:")
+	fmt.Println("------------------------")
+
+	_, _, err = divmod(5, 0)
+	// Compare with the sentinel instead of the error string
+	fmt.Println("errors.Is(err, apperr.ErrDivideByZero) =", errors.Is(err, apperr.ErrDivideByZero))
+	// Get the operands out of the structured error
+	var opErr *apperr.OpError
+	if errors.As(err, &opErr) {
+		fmt.Println("errors.As(err, &opErr) =>", "Op =", opErr.Op, "Operands =", opErr.Operands)
+	}
+	fmt.Println("apperr.Describe(err) =>", apperr.Describe(err).JSON())
+	fmt.Println()
+
 	// Example of Function With Named Return Values
 	// --------------------------------------------
 	fmt.Println("Example of Function With Named Return Values:")
@@ -94,6 +194,50 @@ func main() {
 	fmt.Println("divmodNamed(5, 2) =>", "resX =", resX, "modY =", modY)
 	fmt.Println()
 
+	// Example of Retrying a Function That Returns an Error
+	// ----------------------------------------------------
+	fmt.Println("Example of Retrying a Function That Returns an Error:")
+	fmt.Println("----------------------------------------------------")
+
+	// Fail twice before succeeding, to simulate a transient failure
+	dens := []int{0, 0, 2}
+	attempt := 0
+	resRetry, errRetry := resilience.Retry(func() ([2]int, error) {
+		den := dens[attempt]
+		attempt++
+		q, r, err := divmod(5, den)
+		fmt.Println("\tAttempt", attempt, "with den =", den, "=> err =", err)
+		return [2]int{q, r}, err
+	}, resilience.Policy{
+		MaxAttempts: 5,
+		BaseDelay:   time.Millisecond,
+		Jitter:      0.2,
+	})
+	fmt.Println("Retry(divmod) =>", resRetry, errRetry)
+
+	// A circuit breaker stops calling a function that keeps failing
+	breaker := resilience.NewBreaker(resilience.BreakerConfig{
+		FailureThreshold: 2,
+		OpenTimeout:      time.Minute,
+	})
+	for i := range 4 {
+		_, errBreaker := resilience.Call(breaker, func() (int, error) {
+			_, _, err := divmod(5, 0)
+			return 0, err
+		})
+		fmt.Println("\tCall", i+1, "=> err =", errBreaker, "state =", breaker.State())
+	}
+
+	// A timeout gives up on a function that takes too long
+	_, errTimeout := resilience.WithTimeout(context.Background(), nil, 10*time.Millisecond,
+		func(ctx context.Context) (int, error) {
+			// Simulate slow work that stops when the context is canceled
+			<-ctx.Done()
+			return 0, ctx.Err()
+		})
+	fmt.Println("WithTimeout(slow function) =>", errTimeout)
+	fmt.Println()
+
 	// Example of Declaring a Function Variable
 	// ----------------------------------------
 	fmt.Println("Example of Declaring a Function Variable:")
@@ -113,52 +257,96 @@ func main() {
 	fmt.Println("myFuncVar(\"Hello\") using f2:", res)
 	fmt.Println()
 
+	// Example of Decorating a Function Variable
+	// -----------------------------------------
+	fmt.Println("Example of Decorating a Function Variable:")
+	fmt.Println("------------------------------------------")
+
+	// Decorators wrap the function value without changing f1 or f2
+	metrics := &decorate.Metrics{}
+	myFuncVar = decorate.Chain(
+		f2,
+		decorate.WithMetrics(metrics),
+		decorate.WithLogging(log.New(os.Stdout, "\t", 0), "f2"),
+		decorate.WithTiming(func(d time.Duration) { fmt.Println("\tcall took less than 1s:", d < time.Second) }),
+		decorate.WithRecover(-1, nil),
+		decorate.WithCache(),
+	)
+	myFuncVar("Hello")
+	// The second call is served from the cache
+	myFuncVar("Hello")
+	fmt.Println("Decorated f2 metrics:", metrics)
+	fmt.Println()
+
 	// Example of a Simple Calculator With Functions
 	// ---------------------------------------------
 	fmt.Println("Example of a Simple Calculator With Functions:")
 	fmt.Println("----------------------------------------------")
 
-	// Declaring a Function Type
-	type opFunc func(int, int) int
-
-	opMap := map[string]opFunc{
-		"+": add,
-		"-": sub,
-		"*": mul,
-		"/": divs,
-	}
 	expressions := [][]string{
 		{"2", "+", "3"},
 		{"2", "-", "3"},
 		{"2", "*", "3"},
 		{"2", "/", "3"},
 		{"2", "%", "3"},
+		{"2", "^", "3"},
 		{"two", "+", "three"},
 		{"5"},
 	}
+	// The calculator takes the division mode used by / and %
 	for _, expr := range expressions {
-		if len(expr) != 3 {
-			fmt.Println("Invalid expression:", expr)
-			continue
-		}
-		p1, err := strconv.Atoi(expr[0])
+		result, err := calculate(expr, division.Truncated)
 		if err != nil {
-			fmt.Println(err)
-			continue
-		}
-		op := expr[1]
-		opFunc, ok := opMap[op]
-		if !ok {
-			fmt.Println("Unsupported Operator:", op)
+			fmt.Println(apperr.Describe(err))
 			continue
 		}
-		p2, err := strconv.Atoi(expr[2])
+		fmt.Println(expr, "=", result)
+	}
+	fmt.Println()
+
+	// Example of Checked Arithmetic
+	// -----------------------------
+	fmt.Println("Example of Checked Arithmetic:")
+	fmt.Println("------------------------------")
+
+	// The built-in operators wrap around silently
+	fmt.Println("add(math.MaxInt, 1) =", add(math.MaxInt, 1))
+	_, err = checked.Add(math.MaxInt, 1)
+	fmt.Println("checked.Add(math.MaxInt, 1) =>", err)
+	_, err = checked.Sum[int8](100, 20, 10)
+	fmt.Println("checked.Sum[int8](100, 20, 10) =>", err)
+	fmt.Println("checked.SaturatingAdd[int8](100, 100) =", checked.SaturatingAdd[int8](100, 100))
+	fmt.Println("checked.SaturatingSub[uint8](10, 20) =", checked.SaturatingSub[uint8](10, 20))
+	fmt.Println("checked.WrappingMul[uint8](16, 16) =", checked.WrappingMul[uint8](16, 16))
+	fmt.Println()
+
+	// Example of Selecting a Division Mode
+	// ------------------------------------
+	fmt.Println("Example of Selecting a Division Mode:")
+	fmt.Println("-------------------------------------")
+
+	divExprs := [][]string{
+		{"-5", "/", "2"},
+		{"-5", "%", "2"},
+		{"5", "/", "-2"},
+		{"5", "%", "-2"},
+		{"5", "/", "0"},
+	}
+	for _, modeName := range []string{"truncated", "floored", "euclidean"} {
+		mode, err := division.ParseMode(modeName)
 		if err != nil {
 			fmt.Println(err)
 			continue
 		}
-		result := opFunc(p1, p2)
-		fmt.Println(expr, "=", result)
+		fmt.Println("Mode:", mode)
+		for _, expr := range divExprs {
+			result, err := calculate(expr, mode)
+			if err != nil {
+				fmt.Println("\t", apperr.Describe(err))
+				continue
+			}
+			fmt.Println("\t", expr, "=", result)
+		}
 	}
 	fmt.Println()
 
@@ -274,14 +462,13 @@ func main() {
 	fmt.Println("------------------------------------")
 
 	// Make sure a filename was passed as argument: make try ARGS="<filename>"
-	// Args[0] is the name of the program
-	if len(os.Args) < 2 {
-		log.Fatal("Error: No file was specified")
+	if flag.NArg() < 1 {
+		log.Fatal(apperr.Describe(fmt.Errorf("%w: no file was specified", apperr.ErrMissingArgument)))
 	}
 	// Open the file: Read-only
-	fl, err := os.Open(os.Args[1])
+	fl, err := os.Open(flag.Arg(0))
 	if err != nil {
-		log.Fatal(err)
+		log.Fatal(apperr.Describe(err))
 	}
 	// Close the file after using it
 	// This must be run no matter any errors in the program
@@ -298,7 +485,7 @@ func main() {
 		os.Stdout.Write(data[:count])
 		if err != nil {
 			if err != io.EOF {
-				log.Fatal(err)
+				log.Fatal(apperr.Describe(err))
 			}
 			break
 		}
@@ -313,6 +500,50 @@ func main() {
 	deferExample()
 	fmt.Println()
 
+	// Example of Tracing defer
+	// ------------------------
+	fmt.Println("Example of Tracing defer:")
+	fmt.Println("-------------------------")
+
+	// A fake clock that advances 1ms per reading, for stable timings
+	tick := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracer := calltrace.New(func() time.Time {
+		tick = tick.Add(time.Millisecond)
+		return tick
+	})
+	tracedDeferExample(tracer)
+	tracer.WriteTree(os.Stdout)
+	fmt.Println()
+
+	// Example of a Cleanup Stack
+	// --------------------------
+	fmt.Println("Example of a Cleanup Stack:")
+	fmt.Println("---------------------------")
+
+	// Each step acquires a resource and pushes its cleanup
+	acquire := func(name string, fail bool) func(*cleanup.Closer) error {
+		return func(c *cleanup.Closer) error {
+			if fail {
+				return fmt.Errorf("cannot acquire %s", name)
+			}
+			fmt.Println("\tAcquired", name)
+			c.AddFunc(func() { fmt.Println("\tReleased", name) })
+			return nil
+		}
+	}
+
+	// All steps succeed: the caller closes the stack later, in reverse order
+	stack, err := cleanup.Build(acquire("database", false), acquire("cache", false), acquire("server", false))
+	if err == nil {
+		fmt.Println("Setup succeeded with", stack.Len(), "cleanups")
+		fmt.Println("Close() =>", stack.Close())
+	}
+
+	// The last step fails: the previous steps are rolled back
+	_, err = cleanup.Build(acquire("database", false), acquire("cache", false), acquire("server", true))
+	fmt.Println("Setup failed =>", err)
+	fmt.Println()
+
 	// Example of Call-By-Value
 	// ------------------------
 	fmt.Println("Example of Call-By-Value:")
@@ -386,16 +617,16 @@ func div(num int, den int) int {
 // Example of a Function With Optional and Named Parameters
 // --------------------------------------------------------
 
-type FuncParams struct {
-	FirstName string
-	LastName  string
-	Age       int
-}
+// The struct and its tags are defined in the params package.
+type FuncParams = params.FuncParams
 
 // A test function for Optional and Named Parameters.
-func MyFunc(params FuncParams) error {
+func MyFunc(p FuncParams) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
 	// Do something
-	fmt.Println("Passed parameters:", params)
+	fmt.Println("Passed parameters:", p)
 	return nil
 }
 
@@ -417,7 +648,7 @@ func addNums(nums ...int) int {
 // Divide an integer by another integer and return the result and the mod.
 func divmod(num, den int) (int, int, error) {
 	if den == 0 {
-		return 0, 0, errors.New("cannot divide by 0")
+		return 0, 0, &apperr.OpError{Op: "divmod", Operands: []any{num, den}, Err: apperr.ErrDivideByZero}
 	}
 	return num / den, num % den, nil
 }
@@ -428,7 +659,7 @@ func divmod(num, den int) (int, int, error) {
 // Divide an integer by another integer and return the result and the mod.
 func divmodNamed(num, den int) (res int, mod int, err error) {
 	if den == 0 {
-		err = errors.New("cannot divide by 0")
+		err = &apperr.OpError{Op: "divmodNamed", Operands: []any{num, den}, Err: apperr.ErrDivideByZero}
 		// Returning multiple values: Default to zero-values
 		return res, mod, err
 	}
@@ -456,10 +687,51 @@ func f2(s string) int {
 // Example of a Simple Calculator With Functions
 // ---------------------------------------------
 
-func add(i int, j int) int  { return i + j }
-func sub(i int, j int) int  { return i - j }
-func mul(i int, j int) int  { return i * j }
-func divs(i int, j int) int { return i / j }
+func add(i int, j int) int { return i + j }
+func sub(i int, j int) int { return i - j }
+func mul(i int, j int) int { return i * j }
+
+// Evaluate an expression of the calculator: operand operator operand.
+// The quotient of / and the remainder of % are rounded with the given division mode.
+func calculate(expr []string, mode division.Mode) (int, error) {
+	// Declaring a Function Type
+	type opFunc func(int, int) int
+
+	opMap := map[string]opFunc{
+		"+": add,
+		"-": sub,
+		"*": mul,
+	}
+	if len(expr) != 3 {
+		return 0, &apperr.ExprError{Expr: expr, Err: apperr.ErrInvalidExpression}
+	}
+	p1, err := strconv.Atoi(expr[0])
+	if err != nil {
+		return 0, &apperr.ExprError{Expr: expr, Err: fmt.Errorf("%w: %w", apperr.ErrInvalidOperand, err)}
+	}
+	op := expr[1]
+	fn, ok := opMap[op]
+	if !ok && op != "/" && op != "%" {
+		return 0, &apperr.ExprError{Expr: expr, Err: fmt.Errorf("%w: %s", apperr.ErrUnsupportedOperator, op)}
+	}
+	p2, err := strconv.Atoi(expr[2])
+	if err != nil {
+		return 0, &apperr.ExprError{Expr: expr, Err: fmt.Errorf("%w: %w", apperr.ErrInvalidOperand, err)}
+	}
+	if ok {
+		return fn(p1, p2), nil
+	}
+
+	// Division goes through the division package, which reports a 0 divisor instead of panicking
+	q, r, err := division.DivMod(p1, p2, mode)
+	if err != nil {
+		return 0, &apperr.ExprError{Expr: expr, Err: err}
+	}
+	if op == "%" {
+		return r, nil
+	}
+	return q, nil
+}
 
 // Example of Function That Returns a Closure
 // ------------------------------------------
@@ -489,6 +761,25 @@ func deferExample() int {
 	return a
 }
 
+// Same as deferExample, but recording the calls and the deferred arguments.
+func tracedDeferExample(t *calltrace.Tracer) int {
+	defer t.Enter("tracedDeferExample")()
+	a := 10
+	// The argument of a deferred call is evaluated when defer runs, not when the call runs
+	t.Event("defer registered", "val", a)
+	defer func(val int) {
+		defer t.Enter("first deferred func", "val", val)()
+	}(a)
+	a = 20
+	t.Event("defer registered", "val", a)
+	defer func(val int) {
+		defer t.Enter("second deferred func", "val", val)()
+	}(a)
+	a = 30
+	t.Event("return", "a", a)
+	return a
+}
+
 // AVAILABLE COMMANDS
 // ------------------
 //  make ARGS=./src/textfiles/example.txt       Default to `make try`
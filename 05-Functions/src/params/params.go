@@ -0,0 +1,47 @@
+// Package params holds the parameters of MyFunc and a functional-options constructor for them.
+package params
+
+import "github.com/maevadevs/Go-Learning/Functions/src/validate"
+
+// The parameters of MyFunc.
+type FuncParams struct {
+	FirstName string `config:"first_name" validate:"max=50" default:"Anonymous"`
+	LastName  string `config:"last_name" validate:"required,max=50"`
+	Age       int    `config:"age" validate:"min=0,max=150"`
+}
+
+// A function that sets one of the parameters.
+type Option func(*FuncParams)
+
+// Set the first name.
+func WithFirstName(name string) Option {
+	return func(p *FuncParams) { p.FirstName = name }
+}
+
+// Set the last name.
+func WithLastName(name string) Option {
+	return func(p *FuncParams) { p.LastName = name }
+}
+
+// Set the age.
+func WithAge(age int) Option {
+	return func(p *FuncParams) { p.Age = age }
+}
+
+// Build validated parameters.
+// Defaults are set first, so an option always wins over a default.
+func NewParams(opts ...Option) (FuncParams, error) {
+	var p FuncParams
+	if err := validate.Defaults(&p); err != nil {
+		return p, err
+	}
+	for _, opt := range opts {
+		opt(&p)
+	}
+	return p, p.Validate()
+}
+
+// Check the parameters against their `validate` tags.
+func (p FuncParams) Validate() error {
+	return validate.Struct(p)
+}
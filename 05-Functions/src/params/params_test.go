@@ -0,0 +1,47 @@
+package params_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/maevadevs/Go-Learning/Functions/src/params"
+	"github.com/maevadevs/Go-Learning/Functions/src/validate"
+)
+
+func TestNewParams(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []params.Option
+		want params.FuncParams
+		errs []string
+	}{
+		{"defaults", []params.Option{params.WithLastName("Doe")},
+			params.FuncParams{FirstName: "Anonymous", LastName: "Doe"}, nil},
+		{"options override the defaults", []params.Option{params.WithFirstName("Ann"), params.WithLastName("Lee"), params.WithAge(30)},
+			params.FuncParams{FirstName: "Ann", LastName: "Lee", Age: 30}, nil},
+		{"one failure", []params.Option{params.WithAge(30)},
+			params.FuncParams{FirstName: "Anonymous", Age: 30}, []string{"LastName:required"}},
+		// Every failed field is reported
+		{"aggregated failures", []params.Option{params.WithAge(-1)},
+			params.FuncParams{FirstName: "Anonymous", Age: -1}, []string{"LastName:required", "Age:min"}},
+	}
+	for _, tt := range tests {
+		p, err := params.NewParams(tt.opts...)
+		if p != tt.want {
+			t.Errorf("%s: NewParams = %+v, want %+v", tt.name, p, tt.want)
+		}
+		var got []string
+		var errs validate.Errors
+		if errors.As(err, &errs) {
+			for _, fe := range errs {
+				got = append(got, fe.Field+":"+fe.Rule)
+			}
+		} else if err != nil {
+			t.Fatalf("%s: NewParams = %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(got, tt.errs) {
+			t.Errorf("%s: failed %v, want %v", tt.name, got, tt.errs)
+		}
+	}
+}
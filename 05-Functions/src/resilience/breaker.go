@@ -0,0 +1,177 @@
+package resilience
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Returned by Call when the breaker rejects a call without running it.
+var ErrOpen = errors.New("resilience: circuit breaker is open")
+
+// Recorded as the outcome of a call that panicked.
+var errPanicked = errors.New("resilience: function panicked")
+
+// The state of a circuit breaker.
+type State int
+
+const (
+	// Calls go through. Failures are counted.
+	Closed State = iota
+	// Calls are rejected until the open timeout elapses.
+	Open
+	// A limited number of probe calls go through to test recovery.
+	HalfOpen
+)
+
+// Print the name of the state.
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Configure a Breaker.
+type BreakerConfig struct {
+	// Consecutive failures that open the breaker. Values below 1 mean 1.
+	FailureThreshold int
+	// How long the breaker stays open before probing.
+	OpenTimeout time.Duration
+	// Consecutive successful probes needed to close the breaker. Values below 1 mean 1.
+	HalfOpenProbes int
+	// Source of time. Nil means RealClock.
+	Clock Clock
+}
+
+// A circuit breaker. Safe for concurrent use.
+type Breaker struct {
+	cfg       BreakerConfig
+	mu        sync.Mutex
+	state     State
+	failures  int
+	successes int
+	inFlight  int
+	openedAt  time.Time
+	// Incremented on every change of state, so that calls that started
+	// in a previous state do not count in the current one
+	gen uint64
+}
+
+// Create a closed breaker.
+func NewBreaker(cfg BreakerConfig) *Breaker {
+	cfg.Clock = clockOrReal(cfg.Clock)
+	cfg.FailureThreshold = max(cfg.FailureThreshold, 1)
+	cfg.HalfOpenProbes = max(cfg.HalfOpenProbes, 1)
+	return &Breaker{cfg: cfg}
+}
+
+// Return the current state, moving from Open to HalfOpen if the timeout elapsed.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refresh()
+	return b.state
+}
+
+// Move from Open to HalfOpen once the open timeout elapsed. Must hold b.mu.
+func (b *Breaker) refresh() {
+	if b.state == Open && b.cfg.Clock.Now().Sub(b.openedAt) >= b.cfg.OpenTimeout {
+		b.setState(HalfOpen)
+		b.successes = 0
+		b.inFlight = 0
+	}
+}
+
+// Change the state and start a new generation. Must hold b.mu.
+func (b *Breaker) setState(s State) {
+	b.state = s
+	b.gen++
+}
+
+// Decide whether a call may run.
+// Returns the generation to pass to done with the outcome of the call.
+func (b *Breaker) allow() (uint64, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refresh()
+	switch b.state {
+	case Open:
+		return 0, false
+	case HalfOpen:
+		// Only let through as many probes as needed to close
+		if b.inFlight >= b.cfg.HalfOpenProbes-b.successes {
+			return 0, false
+		}
+		b.inFlight++
+	}
+	return b.gen, true
+}
+
+// Record the outcome of a call allowed in generation gen.
+// The outcome of a call that started before the last change of state is ignored.
+func (b *Breaker) done(gen uint64, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if gen == b.gen {
+		b.record(err)
+	}
+}
+
+// Record the outcome of a call allowed in the current state. Must hold b.mu.
+func (b *Breaker) record(err error) {
+	switch b.state {
+	case Closed:
+		if err == nil {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.cfg.FailureThreshold {
+			b.trip()
+		}
+	case HalfOpen:
+		b.inFlight--
+		if err != nil {
+			b.trip()
+			return
+		}
+		b.successes++
+		if b.successes >= b.cfg.HalfOpenProbes {
+			b.setState(Closed)
+			b.failures = 0
+		}
+	}
+}
+
+// Open the breaker. Must hold b.mu.
+func (b *Breaker) trip() {
+	b.setState(Open)
+	b.openedAt = b.cfg.Clock.Now()
+	b.failures = 0
+	b.successes = 0
+}
+
+// Run fn through the breaker.
+// Returns ErrOpen without calling fn if the breaker is open.
+// A panic in fn counts as a failure, then keeps going up the stack.
+func Call[T any](b *Breaker, fn func() (T, error)) (T, error) {
+	gen, ok := b.allow()
+	if !ok {
+		var zero T
+		return zero, ErrOpen
+	}
+
+	// Still errPanicked in the deferred call if fn does not return
+	outcome := errPanicked
+	defer func() { b.done(gen, outcome) }()
+	res, err := fn()
+	outcome = err
+	return res, err
+}
@@ -0,0 +1,31 @@
+// Package resilience provides retry, timeout and circuit-breaker wrappers
+// for functions that return an error.
+// Every wrapper reads time from a Clock so that it can be replaced in tests.
+package resilience
+
+import "time"
+
+// The source of time used by the wrappers.
+type Clock interface {
+	// Return the current time.
+	Now() time.Time
+	// Return a channel that receives once d has elapsed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// The Clock backed by the time package.
+type RealClock struct{}
+
+// Return the current time.
+func (RealClock) Now() time.Time { return time.Now() }
+
+// Return a channel that receives once d has elapsed.
+func (RealClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// Return c, or RealClock if c is nil.
+func clockOrReal(c Clock) Clock {
+	if c == nil {
+		return RealClock{}
+	}
+	return c
+}
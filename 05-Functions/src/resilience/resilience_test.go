@@ -0,0 +1,335 @@
+package resilience_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/maevadevs/Go-Learning/Functions/src/resilience"
+)
+
+// A Clock that only moves when told to.
+// With auto set, After moves the time forward by d and fires at once, so Retry never blocks.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	auto   bool
+	waits  []time.Duration
+	timers []timer
+	// Receives once per call to After
+	waiting chan time.Duration
+}
+
+type timer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock(auto bool) *fakeClock {
+	return &fakeClock{
+		now:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		auto:    auto,
+		waiting: make(chan time.Duration, 100),
+	}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.waits = append(c.waits, d)
+	ch := make(chan time.Time, 1)
+	if c.auto {
+		c.now = c.now.Add(d)
+		ch <- c.now
+	} else {
+		c.timers = append(c.timers, timer{c.now.Add(d), ch})
+	}
+	c.waiting <- d
+	return ch
+}
+
+// Move the time forward and fire the timers that are due.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.timers = slices.DeleteFunc(c.timers, func(t timer) bool {
+		if t.at.After(c.now) {
+			return false
+		}
+		t.ch <- c.now
+		return true
+	})
+}
+
+var errBoom = errors.New("boom")
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy resilience.Policy
+		want   []time.Duration
+	}{
+		{"exponential", resilience.Policy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, Multiplier: 3},
+			[]time.Duration{100 * time.Millisecond, 300 * time.Millisecond, 900 * time.Millisecond, 2700 * time.Millisecond}},
+		{"capped", resilience.Policy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, Multiplier: 3},
+			[]time.Duration{100 * time.Millisecond, 300 * time.Millisecond, 900 * time.Millisecond, time.Second}},
+		{"default multiplier", resilience.Policy{MaxAttempts: 4, BaseDelay: time.Second},
+			[]time.Duration{time.Second, 2 * time.Second, 4 * time.Second}},
+		{"low jitter", resilience.Policy{MaxAttempts: 3, BaseDelay: time.Second, Jitter: 0.2, Rand: func() float64 { return 0 }},
+			[]time.Duration{800 * time.Millisecond, 1600 * time.Millisecond}},
+		{"high jitter", resilience.Policy{MaxAttempts: 3, BaseDelay: time.Second, Jitter: 0.2, Rand: func() float64 { return 0.75 }},
+			[]time.Duration{1100 * time.Millisecond, 2200 * time.Millisecond}},
+		{"single attempt", resilience.Policy{BaseDelay: time.Second}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock(true)
+			tt.policy.Clock = clock
+			calls := 0
+			_, err := resilience.Retry(func() (int, error) {
+				calls++
+				return 0, fmt.Errorf("call %d: %w", calls, errBoom)
+			}, tt.policy)
+			if calls != len(tt.want)+1 {
+				t.Errorf("%d calls, want %d", calls, len(tt.want)+1)
+			}
+			if want := fmt.Sprintf("call %d: boom", calls); err == nil || err.Error() != want {
+				t.Errorf("err = %v, want %s", err, want)
+			}
+			if !slices.Equal(clock.waits, tt.want) {
+				t.Errorf("delays = %v, want %v", clock.waits, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryStops(t *testing.T) {
+	clock := newFakeClock(true)
+	policy := resilience.Policy{MaxAttempts: 5, BaseDelay: time.Second, Clock: clock}
+
+	calls := 0
+	res, err := resilience.Retry(func() (string, error) {
+		calls++
+		if calls < 3 {
+			return "", errBoom
+		}
+		return "ok", nil
+	}, policy)
+	if res != "ok" || err != nil || calls != 3 {
+		t.Errorf("Retry = %q, %v after %d calls, want ok after 3", res, err, calls)
+	}
+
+	// A wrapped permanent error stops the retries and keeps its wrapping
+	calls = 0
+	_, err = resilience.Retry(func() (string, error) {
+		calls++
+		return "", fmt.Errorf("fetch: %w", resilience.Permanent(errBoom))
+	}, policy)
+	if calls != 1 || err == nil || err.Error() != "fetch: boom" || !errors.Is(err, errBoom) {
+		t.Errorf("Retry of a permanent error = %v after %d calls, want fetch: boom after 1", err, calls)
+	}
+	if resilience.Permanent(nil) != nil {
+		t.Error("Permanent(nil) != nil")
+	}
+
+	calls = 0
+	policy.Retryable = func(err error) bool { return !errors.Is(err, errBoom) }
+	_, err = resilience.Retry(func() (string, error) {
+		calls++
+		return "", errBoom
+	}, policy)
+	if calls != 1 || !errors.Is(err, errBoom) {
+		t.Errorf("Retry of a non-retryable error = %v after %d calls, want 1 call", err, calls)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	clock := newFakeClock(false)
+	type outcome struct {
+		val int
+		err error
+	}
+	done := make(chan outcome, 1)
+	causes := make(chan error, 1)
+	go func() {
+		val, err := resilience.WithTimeout(context.Background(), clock, time.Second, func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			causes <- context.Cause(ctx)
+			return 1, ctx.Err()
+		})
+		done <- outcome{val, err}
+	}()
+
+	<-clock.waiting
+	clock.Advance(999 * time.Millisecond)
+	select {
+	case <-done:
+		t.Fatal("timed out before the deadline")
+	case <-time.After(10 * time.Millisecond):
+	}
+	clock.Advance(time.Millisecond)
+	res := <-done
+	if res.val != 0 || !errors.Is(res.err, resilience.ErrTimeout) {
+		t.Errorf("WithTimeout = %d, %v, want 0, ErrTimeout", res.val, res.err)
+	}
+	if cause := <-causes; !errors.Is(cause, resilience.ErrTimeout) {
+		t.Errorf("cause of the context given to fn = %v, want ErrTimeout", cause)
+	}
+
+	// Finishing in time returns the result of fn
+	val, err := resilience.WithTimeout(context.Background(), clock, time.Second, func(context.Context) (int, error) {
+		return 42, errBoom
+	})
+	if val != 42 || !errors.Is(err, errBoom) {
+		t.Errorf("WithTimeout = %d, %v, want 42, boom", val, err)
+	}
+
+	// A canceled parent context stops the wait
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errBoom)
+	unblock := make(chan struct{})
+	defer close(unblock)
+	_, err = resilience.WithTimeout(ctx, clock, time.Second, func(context.Context) (int, error) {
+		<-unblock
+		return 0, nil
+	})
+	if !errors.Is(err, errBoom) {
+		t.Errorf("WithTimeout with a canceled context: err = %v, want boom", err)
+	}
+}
+
+// Call fn through the breaker and report the error.
+func call(b *resilience.Breaker, err error) error {
+	_, got := resilience.Call(b, func() (int, error) { return 0, err })
+	return got
+}
+
+func TestBreakerTransitions(t *testing.T) {
+	clock := newFakeClock(false)
+	b := resilience.NewBreaker(resilience.BreakerConfig{
+		FailureThreshold: 2,
+		OpenTimeout:      time.Second,
+		HalfOpenProbes:   2,
+		Clock:            clock,
+	})
+	steps := []struct {
+		name    string
+		advance time.Duration
+		err     error
+		wantErr error
+		want    resilience.State
+	}{
+		{"success", 0, nil, nil, resilience.Closed},
+		{"first failure", 0, errBoom, errBoom, resilience.Closed},
+		{"success resets the count", 0, nil, nil, resilience.Closed},
+		{"failure", 0, errBoom, errBoom, resilience.Closed},
+		{"second failure in a row", 0, errBoom, errBoom, resilience.Open},
+		{"rejected while open", 999 * time.Millisecond, nil, resilience.ErrOpen, resilience.Open},
+		{"first probe", time.Millisecond, nil, nil, resilience.HalfOpen},
+		{"second probe", 0, nil, nil, resilience.Closed},
+		{"failure", 0, errBoom, errBoom, resilience.Closed},
+		{"failure", 0, errBoom, errBoom, resilience.Open},
+		{"failed probe", time.Second, errBoom, errBoom, resilience.Open},
+		{"open again", 500 * time.Millisecond, nil, resilience.ErrOpen, resilience.Open},
+		{"probe", 500 * time.Millisecond, nil, nil, resilience.HalfOpen},
+		{"probe", 0, nil, nil, resilience.Closed},
+	}
+	for i, st := range steps {
+		clock.Advance(st.advance)
+		if err := call(b, st.err); !errors.Is(err, st.wantErr) {
+			t.Fatalf("step %d, %s: err = %v, want %v", i, st.name, err, st.wantErr)
+		}
+		if got := b.State(); got != st.want {
+			t.Fatalf("step %d, %s: state %v, want %v", i, st.name, got, st.want)
+		}
+	}
+}
+
+func TestBreakerPanic(t *testing.T) {
+	clock := newFakeClock(false)
+	b := resilience.NewBreaker(resilience.BreakerConfig{OpenTimeout: time.Second, Clock: clock})
+	call(b, errBoom)
+	clock.Advance(time.Second)
+	if got := b.State(); got != resilience.HalfOpen {
+		t.Fatalf("state %v, want half-open", got)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("the panic did not go through Call")
+			}
+		}()
+		resilience.Call(b, func() (int, error) { panic("probe panicked") })
+	}()
+	// The panic is a failed probe: the breaker does not wait forever for its result
+	if got := b.State(); got != resilience.Open {
+		t.Errorf("state after a panicking probe %v, want open", got)
+	}
+	clock.Advance(time.Second)
+	if err := call(b, nil); err != nil || b.State() != resilience.Closed {
+		t.Errorf("probe after the panic: %v, state %v, want closed", err, b.State())
+	}
+}
+
+func TestBreakerStaleResult(t *testing.T) {
+	clock := newFakeClock(false)
+	b := resilience.NewBreaker(resilience.BreakerConfig{OpenTimeout: time.Second, Clock: clock})
+
+	// A slow call starts while closed...
+	started, release := make(chan struct{}), make(chan struct{})
+	slow := make(chan error)
+	go func() {
+		_, err := resilience.Call(b, func() (int, error) {
+			close(started)
+			<-release
+			return 0, nil
+		})
+		slow <- err
+	}()
+	<-started
+
+	// ...the breaker opens and becomes half-open meanwhile...
+	call(b, errBoom)
+	clock.Advance(time.Second)
+	if got := b.State(); got != resilience.HalfOpen {
+		t.Fatalf("state %v, want half-open", got)
+	}
+
+	// ...then the slow call succeeds: it was not a probe
+	close(release)
+	if err := <-slow; err != nil {
+		t.Fatal(err)
+	}
+	if got := b.State(); got != resilience.HalfOpen {
+		t.Errorf("state after a stale success %v, want half-open", got)
+	}
+	// The probe slot is still free
+	if err := call(b, nil); err != nil || b.State() != resilience.Closed {
+		t.Errorf("probe: %v, state %v, want closed", err, b.State())
+	}
+}
+
+func TestStateString(t *testing.T) {
+	for s, want := range map[resilience.State]string{
+		resilience.Closed:   "closed",
+		resilience.Open:     "open",
+		resilience.HalfOpen: "half-open",
+		resilience.State(9): "unknown",
+	} {
+		if got := s.String(); got != want {
+			t.Errorf("State(%d).String() = %q, want %q", s, got, want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package resilience
+
+import (
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// Describe how Retry repeats a failing function.
+type Policy struct {
+	// Total number of calls, including the first one. Values below 1 mean 1.
+	MaxAttempts int
+	// Delay before the first retry.
+	BaseDelay time.Duration
+	// Upper bound for any delay. Zero means no bound.
+	MaxDelay time.Duration
+	// Factor applied to the delay after each retry. Values below 1 mean 2.
+	Multiplier float64
+	// Fraction of the delay, between 0 and 1, that is randomized.
+	// With 0.2, a delay of 100ms becomes a value in [80ms, 120ms].
+	Jitter float64
+	// Report whether an error is worth retrying. Nil means every error is.
+	Retryable func(error) bool
+	// Source of time. Nil means RealClock.
+	Clock Clock
+	// Source of randomness in [0, 1). Nil means rand.Float64.
+	Rand func() float64
+}
+
+// Mark an error as final so that Retry returns it immediately.
+// The mark is transparent: the message is the one of err, and errors.Is and errors.As see through it.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err}
+}
+
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Return the delay before retry number n, counting from 0, without jitter.
+func (p Policy) backoff(n int) time.Duration {
+	mult := p.Multiplier
+	if mult < 1 {
+		mult = 2
+	}
+	d := float64(p.BaseDelay)
+	for range n {
+		d *= mult
+		if p.MaxDelay > 0 && d >= float64(p.MaxDelay) {
+			return p.MaxDelay
+		}
+	}
+	if p.MaxDelay > 0 && d > float64(p.MaxDelay) {
+		return p.MaxDelay
+	}
+	return time.Duration(d)
+}
+
+// Return the delay before retry number n with jitter applied.
+func (p Policy) delay(n int) time.Duration {
+	d := p.backoff(n)
+	if p.Jitter <= 0 || d == 0 {
+		return d
+	}
+	rnd := p.Rand
+	if rnd == nil {
+		rnd = rand.Float64
+	}
+	jitter := min(p.Jitter, 1)
+	// Scale into [1-jitter, 1+jitter)
+	return time.Duration(float64(d) * (1 - jitter + 2*jitter*rnd()))
+}
+
+// Call fn until it succeeds, returns a permanent or non-retryable error,
+// or the policy runs out of attempts.
+// On failure, the last error is returned as fn returned it.
+func Retry[T any](fn func() (T, error), policy Policy) (T, error) {
+	clock := clockOrReal(policy.Clock)
+	attempts := max(policy.MaxAttempts, 1)
+	var res T
+	var err error
+	for n := range attempts {
+		res, err = fn()
+		if err == nil {
+			return res, nil
+		}
+		// Return the error whole: the caller may have wrapped the permanent error
+		var perm *permanentError
+		if errors.As(err, &perm) {
+			return res, err
+		}
+		if policy.Retryable != nil && !policy.Retryable(err) {
+			return res, err
+		}
+		if n < attempts-1 {
+			<-clock.After(policy.delay(n))
+		}
+	}
+	return res, err
+}
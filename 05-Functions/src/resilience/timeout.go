@@ -0,0 +1,42 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Returned by WithTimeout when fn does not finish in time.
+var ErrTimeout = errors.New("resilience: timed out")
+
+// Call fn and wait at most d for its result.
+// The context given to fn is canceled with ErrTimeout as cause when the time is up,
+// so fn should return early once ctx.Done() is closed.
+// A nil clock means RealClock.
+func WithTimeout[T any](ctx context.Context, clock Clock, d time.Duration, fn func(context.Context) (T, error)) (T, error) {
+	clock = clockOrReal(clock)
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	type result struct {
+		val T
+		err error
+	}
+	// Buffered so that fn never blocks if nobody is listening anymore
+	done := make(chan result, 1)
+	go func() {
+		val, err := fn(ctx)
+		done <- result{val, err}
+	}()
+
+	var zero T
+	select {
+	case res := <-done:
+		return res.val, res.err
+	case <-clock.After(d):
+		cancel(ErrTimeout)
+		return zero, ErrTimeout
+	case <-ctx.Done():
+		return zero, context.Cause(ctx)
+	}
+}
@@ -0,0 +1,68 @@
+package stats
+
+import "math"
+
+// Compute running statistics over an unbounded stream of values in constant memory.
+// It uses Welford's algorithm, which stays accurate when the mean is large.
+// The zero value is ready to use.
+type Accumulator struct {
+	count    int
+	mean     float64
+	m2       float64
+	min, max float64
+}
+
+// Add a value to the stream.
+func (a *Accumulator) Add(x float64) {
+	a.count++
+	if a.count == 1 {
+		a.min, a.max = x, x
+	} else {
+		a.min = min(a.min, x)
+		a.max = max(a.max, x)
+	}
+	delta := x - a.mean
+	a.mean += delta / float64(a.count)
+	a.m2 += delta * (x - a.mean)
+}
+
+// Return the number of values added.
+func (a *Accumulator) Count() int { return a.count }
+
+// Return the mean of the values added.
+func (a *Accumulator) Mean() (float64, error) {
+	if a.count == 0 {
+		return 0, ErrEmpty
+	}
+	return a.mean, nil
+}
+
+// Return the population variance of the values added.
+func (a *Accumulator) Variance() (float64, error) {
+	if a.count == 0 {
+		return 0, ErrEmpty
+	}
+	return a.m2 / float64(a.count), nil
+}
+
+// Return the sample variance of the values added, with Bessel's correction.
+func (a *Accumulator) SampleVariance() (float64, error) {
+	if a.count < 2 {
+		return 0, ErrEmpty
+	}
+	return a.m2 / float64(a.count-1), nil
+}
+
+// Return the population standard deviation of the values added.
+func (a *Accumulator) StdDev() (float64, error) {
+	v, err := a.Variance()
+	return math.Sqrt(v), err
+}
+
+// Return the smallest and the largest value added.
+func (a *Accumulator) MinMax() (float64, float64, error) {
+	if a.count == 0 {
+		return 0, 0, ErrEmpty
+	}
+	return a.min, a.max, nil
+}
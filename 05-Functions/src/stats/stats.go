@@ -0,0 +1,171 @@
+// Package stats computes descriptive statistics over slices of numbers.
+// For input that does not fit in memory, use an Accumulator instead.
+package stats
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+)
+
+// Every integer and floating-point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Returned when a statistic is undefined for an empty input.
+var ErrEmpty = errors.New("stats: no values")
+
+// Report whether T is a floating-point type.
+func isFloat[T Number]() bool {
+	half := T(1)
+	half /= 2
+	return half != 0
+}
+
+// Add any number of values and return their sum.
+// For floats, the sum uses Kahan compensation to limit rounding errors.
+func Sum[T Number](nums ...T) T {
+	if !isFloat[T]() {
+		var res T
+		for _, n := range nums {
+			res += n
+		}
+		return res
+	}
+	// Kahan summation: c accumulates the low-order bits lost by each addition
+	var sum, c float64
+	for _, n := range nums {
+		y := float64(n) - c
+		t := sum + y
+		c = (t - sum) - y
+		sum = t
+	}
+	return T(sum)
+}
+
+// Return the arithmetic mean.
+func Mean[T Number](nums ...T) (float64, error) {
+	if len(nums) == 0 {
+		return 0, ErrEmpty
+	}
+	// Convert first so that integer sums do not overflow as easily
+	fs := make([]float64, len(nums))
+	for i, n := range nums {
+		fs[i] = float64(n)
+	}
+	return Sum(fs...) / float64(len(nums)), nil
+}
+
+// Return the middle value, or the mean of the two middle values.
+// The input is not modified.
+func Median[T Number](nums ...T) (float64, error) {
+	if len(nums) == 0 {
+		return 0, ErrEmpty
+	}
+	sorted := slices.Clone(nums)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return float64(sorted[mid]), nil
+	}
+	return (float64(sorted[mid-1]) + float64(sorted[mid])) / 2, nil
+}
+
+// Return the most frequent values, sorted in increasing order.
+func Mode[T Number](nums ...T) ([]T, error) {
+	if len(nums) == 0 {
+		return nil, ErrEmpty
+	}
+	counts := map[T]int{}
+	best := 0
+	for _, n := range nums {
+		counts[n]++
+		best = max(best, counts[n])
+	}
+	var modes []T
+	for n, c := range counts {
+		if c == best {
+			modes = append(modes, n)
+		}
+	}
+	slices.Sort(modes)
+	return modes, nil
+}
+
+// Return the population variance.
+func Variance[T Number](nums ...T) (float64, error) {
+	var acc Accumulator
+	for _, n := range nums {
+		acc.Add(float64(n))
+	}
+	return acc.Variance()
+}
+
+// Return the sample variance, with Bessel's correction.
+func SampleVariance[T Number](nums ...T) (float64, error) {
+	var acc Accumulator
+	for _, n := range nums {
+		acc.Add(float64(n))
+	}
+	return acc.SampleVariance()
+}
+
+// Return the population standard deviation.
+func StdDev[T Number](nums ...T) (float64, error) {
+	v, err := Variance(nums...)
+	return math.Sqrt(v), err
+}
+
+// Return the smallest and the largest value.
+func MinMax[T cmp.Ordered](nums ...T) (T, T, error) {
+	if len(nums) == 0 {
+		var zero T
+		return zero, zero, ErrEmpty
+	}
+	return slices.Min(nums), slices.Max(nums), nil
+}
+
+// A half-open range [Lo, Hi) and how many values fell in it.
+// The last bucket of a histogram also includes its Hi.
+type Bucket struct {
+	Lo, Hi float64
+	Count  int
+}
+
+// Split the range of the values into n buckets of equal width and count the values in each.
+// NaN and infinite values have no bucket: they are skipped.
+func Histogram[T Number](n int, nums ...T) ([]Bucket, error) {
+	if n < 1 {
+		return nil, errors.New("stats: histogram needs at least one bucket")
+	}
+	var finite []float64
+	for _, v := range nums {
+		if f := float64(v); !math.IsNaN(f) && !math.IsInf(f, 0) {
+			finite = append(finite, f)
+		}
+	}
+	if len(finite) == 0 {
+		return nil, ErrEmpty
+	}
+	minV, maxV, _ := MinMax(finite...)
+	width := (maxV - minV) / float64(n)
+	buckets := make([]Bucket, n)
+	for i := range buckets {
+		buckets[i].Lo = minV + float64(i)*width
+		buckets[i].Hi = minV + float64(i+1)*width
+	}
+	buckets[n-1].Hi = maxV
+	for _, v := range finite {
+		i := n - 1
+		if width > 0 {
+			// Clamp so that the maximum lands in the last bucket, and rounding never goes below the first
+			i = max(min(int((v-minV)/width), n-1), 0)
+		}
+		buckets[i].Count++
+	}
+	return buckets, nil
+}
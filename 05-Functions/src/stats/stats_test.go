@@ -0,0 +1,103 @@
+package stats_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/maevadevs/Go-Learning/Functions/src/stats"
+)
+
+func TestSumKahan(t *testing.T) {
+	tenths := make([]float64, 10)
+	for i := range tenths {
+		tenths[i] = 0.1
+	}
+	// A naive loop gives 0.9999999999999999
+	if got := stats.Sum(tenths...); got != 1 {
+		t.Errorf("Sum(0.1 x 10) = %v, want 1", got)
+	}
+
+	// Each 1e-16 is lost when added to 1 alone, but not with compensation
+	small := []float64{1}
+	for range 1_000_000 {
+		small = append(small, 1e-16)
+	}
+	if got, want := stats.Sum(small...), 1+1e-10; math.Abs(got-want) > 1e-15 {
+		t.Errorf("Sum(1, 1e-16 x 1e6) = %v, want %v", got, want)
+	}
+
+	if got := stats.Sum(1, 2, 3, math.MaxInt64-6); got != math.MaxInt64 {
+		t.Errorf("Sum of ints = %v, want MaxInt64", got)
+	}
+}
+
+func TestVarianceWelford(t *testing.T) {
+	// A large offset makes the sum of squares lose every significant digit
+	for _, offset := range []float64{0, 1e9, 1e12} {
+		nums := []float64{offset + 4, offset + 7, offset + 13, offset + 16}
+		v, err := stats.Variance(nums...)
+		if err != nil || math.Abs(v-22.5) > 1e-3 {
+			t.Errorf("Variance with offset %g = %v, %v, want 22.5", offset, v, err)
+		}
+		sv, err := stats.SampleVariance(nums...)
+		if err != nil || math.Abs(sv-30) > 1e-3 {
+			t.Errorf("SampleVariance with offset %g = %v, %v, want 30", offset, sv, err)
+		}
+	}
+
+	var acc stats.Accumulator
+	if _, err := acc.Mean(); !errors.Is(err, stats.ErrEmpty) {
+		t.Errorf("Mean of nothing: err = %v, want ErrEmpty", err)
+	}
+	for _, x := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		acc.Add(x)
+	}
+	mean, _ := acc.Mean()
+	sd, _ := acc.StdDev()
+	lo, hi, _ := acc.MinMax()
+	if mean != 5 || sd != 2 || lo != 2 || hi != 9 || acc.Count() != 8 {
+		t.Errorf("Accumulator: mean %v, stddev %v, min %v, max %v, count %d", mean, sd, lo, hi, acc.Count())
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	tests := []struct {
+		name   string
+		n      int
+		nums   []float64
+		counts []int
+	}{
+		{"spread", 3, []float64{1, 2, 3, 3}, []int{1, 1, 2}},
+		{"maximum in last bucket", 2, []float64{0, 10}, []int{1, 1}},
+		{"constant", 3, []float64{5, 5, 5}, []int{0, 0, 3}},
+		{"single", 1, []float64{-2}, []int{1}},
+		{"non-finite skipped", 2, []float64{1, math.NaN(), 2, math.Inf(1), math.Inf(-1)}, []int{1, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hist, err := stats.Histogram(tt.n, tt.nums...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(hist) != len(tt.counts) {
+				t.Fatalf("got %d buckets, want %d", len(hist), len(tt.counts))
+			}
+			for i, b := range hist {
+				if b.Count != tt.counts[i] {
+					t.Errorf("bucket %d [%v, %v]: count %d, want %d", i, b.Lo, b.Hi, b.Count, tt.counts[i])
+				}
+			}
+		})
+	}
+
+	if _, err := stats.Histogram(3, math.NaN(), math.Inf(1)); !errors.Is(err, stats.ErrEmpty) {
+		t.Errorf("Histogram of non-finite values: err = %v, want ErrEmpty", err)
+	}
+	if _, err := stats.Histogram[float64](3); !errors.Is(err, stats.ErrEmpty) {
+		t.Errorf("Histogram of nothing: err = %v, want ErrEmpty", err)
+	}
+	if _, err := stats.Histogram(0, 1.0); err == nil {
+		t.Error("Histogram with 0 buckets: no error")
+	}
+}
@@ -0,0 +1,212 @@
+// Package validate checks struct fields against rules declared in struct tags
+// and fills unset fields from default values declared in struct tags.
+//
+// Rules are declared in the `validate` tag, separated by commas:
+//
+//	required   The field must not be its zero value
+//	min=N      Numbers must be >= N, strings must have at least N runes
+//	max=N      Numbers must be <= N, strings must have at most N runes
+//
+// Defaults are declared in the `default` tag:
+//
+//	Name string `default:"Anonymous"`
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// A single field that failed a rule.
+type FieldError struct {
+	Field string
+	Rule  string
+	Param string
+	Value any
+}
+
+// Describe the failed rule.
+func (e *FieldError) Error() string {
+	switch e.Rule {
+	case "required":
+		return fmt.Sprintf("%s is required", e.Field)
+	case "min":
+		return fmt.Sprintf("%s must be at least %s, got %v", e.Field, e.Param, e.Value)
+	case "max":
+		return fmt.Sprintf("%s must be at most %s, got %v", e.Field, e.Param, e.Value)
+	default:
+		return fmt.Sprintf("%s failed rule %q", e.Field, e.Rule)
+	}
+}
+
+// All the fields that failed validation.
+type Errors []*FieldError
+
+// Join the messages of all failed fields.
+func (errs Errors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, e := range errs {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Allow errors.Is and errors.As to look into each field error.
+func (errs Errors) Unwrap() []error {
+	res := make([]error, len(errs))
+	for i, e := range errs {
+		res[i] = e
+	}
+	return res
+}
+
+// Returned when the value to check is not a struct or a pointer to a struct.
+var ErrNotStruct = errors.New("validate: value is not a struct")
+
+// Returned when a tag cannot be understood.
+var ErrBadTag = errors.New("validate: invalid tag")
+
+// Check every field of v against its `validate` tag.
+// Returns an Errors value listing all failed fields, or nil.
+func Struct(v any) error {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+	var errs Errors
+	rt := rv.Type()
+	for i := range rt.NumField() {
+		sf := rt.Field(i)
+		tag, ok := sf.Tag.Lookup("validate")
+		if !ok || !sf.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+		for rule := range strings.SplitSeq(tag, ",") {
+			name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+			fe, err := check(sf.Name, fv, name, param)
+			if err != nil {
+				return err
+			}
+			if fe != nil {
+				errs = append(errs, fe)
+				// Report at most one failure per field
+				break
+			}
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Apply one rule to one field.
+// Returns a FieldError if the rule fails, or an error if the rule is invalid.
+func check(field string, fv reflect.Value, rule, param string) (*FieldError, error) {
+	fail := &FieldError{Field: field, Rule: rule, Param: param, Value: fv.Interface()}
+	switch rule {
+	case "":
+		return nil, nil
+	case "required":
+		if fv.IsZero() {
+			return fail, nil
+		}
+		return nil, nil
+	case "min", "max":
+		limit, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s on field %s: %v", ErrBadTag, rule, field, err)
+		}
+		n, ok := measure(fv)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s on field %s of kind %s", ErrBadTag, rule, field, fv.Kind())
+		}
+		if (rule == "min" && n < limit) || (rule == "max" && n > limit) {
+			return fail, nil
+		}
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown rule %q on field %s", ErrBadTag, rule, field)
+	}
+}
+
+// Return the number that min and max compare against: the value of a number,
+// the rune count of a string, or the length of a slice or map.
+func measure(fv reflect.Value) (float64, bool) {
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(fv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(fv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return fv.Float(), true
+	case reflect.String:
+		return float64(utf8.RuneCountInString(fv.String())), true
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return float64(fv.Len()), true
+	default:
+		return 0, false
+	}
+}
+
+// Set every zero-valued field of the struct pointed to by ptr from its `default` tag.
+func Defaults(ptr any) error {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+	for i := range rt.NumField() {
+		sf := rt.Field(i)
+		def, ok := sf.Tag.Lookup("default")
+		if !ok || !sf.IsExported() || !rv.Field(i).IsZero() {
+			continue
+		}
+		if err := SetString(rv.Field(i), def); err != nil {
+			return fmt.Errorf("%w: default on field %s: %v", ErrBadTag, sf.Name, err)
+		}
+	}
+	return nil
+}
+
+// Parse s into fv according to the kind of fv.
+// Supports strings, booleans, integers and floats.
+func SetString(fv reflect.Value, s string) error {
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported kind %s", fv.Kind())
+	}
+	return nil
+}
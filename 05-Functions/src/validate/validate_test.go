@@ -0,0 +1,176 @@
+package validate_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/maevadevs/Go-Learning/Functions/src/validate"
+)
+
+type user struct {
+	Name string   `validate:"required,min=2,max=4"`
+	Age  int      `validate:"min=0,max=150"`
+	Tags []string `validate:"max=2"`
+	Note string
+	// Unexported: never checked
+	score int `validate:"min=100"`
+}
+
+func TestStruct(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  []string
+	}{
+		{"valid", user{Name: "Ann", Age: 30}, nil},
+		{"valid pointer", &user{Name: "Ann", Tags: []string{"a", "b"}}, nil},
+		// Only the first failure of a field is reported
+		{"required", user{}, []string{"Name:required"}},
+		{"min string", user{Name: "A"}, []string{"Name:min"}},
+		{"max string in runes", user{Name: "héllo"}, []string{"Name:max"}},
+		{"runes not bytes", user{Name: "日本語"}, nil},
+		{"min int", user{Name: "Ann", Age: -1}, []string{"Age:min"}},
+		{"max int", user{Name: "Ann", Age: 151}, []string{"Age:max"}},
+		{"max slice", user{Name: "Ann", Tags: []string{"a", "b", "c"}}, []string{"Tags:max"}},
+		{"several fields", user{Age: 200, Tags: make([]string, 3)}, []string{"Name:required", "Age:max", "Tags:max"}},
+	}
+	for _, tt := range tests {
+		err := validate.Struct(tt.value)
+		var got []string
+		var errs validate.Errors
+		if errors.As(err, &errs) {
+			for _, fe := range errs {
+				got = append(got, fe.Field+":"+fe.Rule)
+			}
+		} else if err != nil {
+			t.Fatalf("%s: Struct = %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: failed %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestStructErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  error
+	}{
+		{"unknown rule", struct {
+			A int `validate:"positive"`
+		}{}, validate.ErrBadTag},
+		{"bad min", struct {
+			A int `validate:"min=x"`
+		}{}, validate.ErrBadTag},
+		{"bad max", struct {
+			A int `validate:"max"`
+		}{}, validate.ErrBadTag},
+		{"min on a bool", struct {
+			A bool `validate:"min=1"`
+		}{}, validate.ErrBadTag},
+		{"int", 3, validate.ErrNotStruct},
+		{"pointer to int", new(3), validate.ErrNotStruct},
+	}
+	for _, tt := range tests {
+		if err := validate.Struct(tt.value); !errors.Is(err, tt.want) {
+			t.Errorf("%s: Struct = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestUnwrap(t *testing.T) {
+	err := validate.Struct(user{Name: "Ann", Age: 151})
+	var fe *validate.FieldError
+	if !errors.As(err, &fe) {
+		t.Fatalf("errors.As(%v) found no *FieldError", err)
+	}
+	if fe.Field != "Age" || fe.Rule != "max" || fe.Param != "150" || fe.Value != 151 {
+		t.Errorf("FieldError = %+v", fe)
+	}
+	if want := "Age must be at most 150, got 151"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+type settings struct {
+	Name    string  `default:"Anonymous"`
+	Port    int     `default:"8080"`
+	Debug   bool    `default:"true"`
+	Ratio   float64 `default:"0.5"`
+	Plain   string
+	private string `default:"hidden"`
+}
+
+func TestDefaults(t *testing.T) {
+	var s settings
+	if err := validate.Defaults(&s); err != nil {
+		t.Fatal(err)
+	}
+	if want := (settings{Name: "Anonymous", Port: 8080, Debug: true, Ratio: 0.5}); s != want {
+		t.Errorf("Defaults = %+v, want %+v", s, want)
+	}
+
+	// Fields that are already set are kept
+	s = settings{Name: "Ann", Port: 1}
+	if err := validate.Defaults(&s); err != nil || s.Name != "Ann" || s.Port != 1 || !s.Debug {
+		t.Errorf("Defaults over set fields = %+v, %v", s, err)
+	}
+
+	bad := struct {
+		Port int `default:"eighty"`
+	}{}
+	if err := validate.Defaults(&bad); !errors.Is(err, validate.ErrBadTag) {
+		t.Errorf("Defaults with an unparsable default = %v, want ErrBadTag", err)
+	}
+	if err := validate.Defaults(settings{}); !errors.Is(err, validate.ErrNotStruct) {
+		t.Errorf("Defaults of a struct value = %v, want ErrNotStruct", err)
+	}
+}
+
+func TestSetString(t *testing.T) {
+	tests := []struct {
+		s    string
+		want any
+	}{
+		{"text", "text"},
+		{"true", true},
+		{"-12", int(-12)},
+		{"-128", int8(-128)},
+		{"300", int16(300)},
+		{"7", int32(7)},
+		{"-9000000000", int64(-9000000000)},
+		{"12", uint(12)},
+		{"255", uint8(255)},
+		{"65535", uint16(65535)},
+		{"7", uint32(7)},
+		{"18446744073709551615", uint64(18446744073709551615)},
+		{"1.5", float32(1.5)},
+		{"-0.25", float64(-0.25)},
+	}
+	for _, tt := range tests {
+		fv := reflect.New(reflect.TypeOf(tt.want)).Elem()
+		if err := validate.SetString(fv, tt.s); err != nil || fv.Interface() != tt.want {
+			t.Errorf("SetString(%T, %q) = %v, %v, want %v", tt.want, tt.s, fv.Interface(), err, tt.want)
+		}
+	}
+
+	errTests := []struct {
+		s     string
+		value any
+	}{
+		{"yes", false},
+		{"128", int8(0)},
+		{"-1", uint(0)},
+		{"1.5", 0},
+		{"x", 0.0},
+		{"a", []string{}},
+	}
+	for _, tt := range errTests {
+		fv := reflect.New(reflect.TypeOf(tt.value)).Elem()
+		if err := validate.SetString(fv, tt.s); err == nil {
+			t.Errorf("SetString(%T, %q) = %v, want an error", tt.value, tt.s, fv.Interface())
+		}
+	}
+}